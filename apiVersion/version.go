@@ -0,0 +1,184 @@
+package apiVersion
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"myapp/customError"
+	"myapp/teq"
+)
+
+const contextKey = "api_version"
+
+// Version is one version of the API, the dates are set once it is on its way out.
+type Version struct {
+	Name        string
+	Deprecation time.Time
+	Sunset      time.Time
+}
+
+// Versions routes the requests of every version under prefix. The first version is served by the routes
+// registered on prefix itself, a later version only registers the routes it changes on its Group and
+// falls back to the older versions for the others.
+type Versions struct {
+	e        *echo.Echo
+	prefix   string
+	versions []Version
+
+	once   sync.Once
+	routes map[string]bool
+}
+
+func New(e *echo.Echo, prefix string, versions ...Version) *Versions {
+	if len(versions) == 0 {
+		panic("apiVersion: no version")
+	}
+
+	return &Versions{e: e, prefix: prefix, versions: versions}
+}
+
+// Group registers the routes of the version name which differ from the older versions.
+func (v *Versions) Group(name string, m ...echo.MiddlewareFunc) *echo.Group {
+	if i := v.index(name); i <= 0 {
+		panic(fmt.Sprintf("apiVersion: %q is not a version after %s", name, v.versions[0].Name))
+	}
+
+	return v.e.Group(v.prefix+"/"+name, m...)
+}
+
+// Latest is the name of the newest version.
+func (v *Versions) Latest() string {
+	return v.versions[len(v.versions)-1].Name
+}
+
+// FromContext is the version the request asked for.
+func FromContext(c echo.Context) string {
+	name, _ := c.Get(contextKey).(string)
+
+	return name
+}
+
+// Resolve must run before the router, e.Pre(versions.Resolve()). It takes the version from the path,
+// `/api/v2/cards`, else from the Accept header, `application/json; version=2`, else uses the first one.
+// The path is rewritten to the route of the newest version up to the one asked for.
+func (v *Versions) Resolve() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			rest, ok := v.under(req.URL.Path)
+			if !ok {
+				return next(c)
+			}
+
+			c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+			i := 0
+			if segment, after, _ := strings.Cut(strings.TrimPrefix(rest, "/"), "/"); v.index(segment) >= 0 {
+				i, rest = v.index(segment), strings.TrimSuffix("/"+after, "/")
+			} else if name, ok := accepted(req.Header.Get(echo.HeaderAccept)); ok {
+				if i = v.index(name); i < 0 {
+					return teq.Response.Error(c, customError.ErrUnsupportedVersion(name))
+				}
+			}
+
+			version := v.versions[i]
+			c.Set(contextKey, version.Name)
+			v.deprecate(c, version)
+
+			req.URL.Path = v.route(req.Method, rest, i)
+			req.URL.RawPath = ""
+
+			return next(c)
+		}
+	}
+}
+
+// under returns the path after the prefix, reporting whether the path is under it.
+func (v *Versions) under(path string) (string, bool) {
+	rest := strings.TrimPrefix(path, v.prefix)
+	if rest == path || (rest != "" && rest[0] != '/') {
+		return "", false
+	}
+
+	return strings.TrimSuffix(rest, "/"), true
+}
+
+// route is the path of the newest version up to i which has a route for rest, the first version otherwise.
+func (v *Versions) route(method string, rest string, i int) string {
+	for ; i > 0; i-- {
+		path := v.prefix + "/" + v.versions[i].Name + rest
+		if v.routed(method, path) {
+			return path
+		}
+	}
+
+	return v.prefix + rest
+}
+
+func (v *Versions) routed(method string, path string) bool {
+	v.once.Do(func() {
+		// the catch-all routes of the groups with middlewares are not routes of a version
+		notFound := runtime.FuncForPC(reflect.ValueOf(echo.NotFoundHandler).Pointer()).Name()
+
+		v.routes = make(map[string]bool)
+		for _, route := range v.e.Routes() {
+			if route.Name != notFound {
+				v.routes[route.Method+" "+route.Path] = true
+			}
+		}
+	})
+
+	c := v.e.NewContext(nil, nil)
+	v.e.Router().Find(method, path, c)
+
+	return v.routes[method+" "+c.Path()]
+}
+
+func (v *Versions) index(name string) int {
+	for i := range v.versions {
+		if v.versions[i].Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// deprecate announces the versions on their way out, https://www.rfc-editor.org/rfc/rfc9745 and rfc8594.
+func (v *Versions) deprecate(c echo.Context, version Version) {
+	header := c.Response().Header()
+	if !version.Deprecation.IsZero() {
+		header.Set("Deprecation", fmt.Sprintf("@%d", version.Deprecation.Unix()))
+		header.Set("Link", fmt.Sprintf(`<%s/%s>; rel="successor-version"`, v.prefix, v.Latest()))
+	}
+
+	if !version.Sunset.IsZero() {
+		header.Set("Sunset", version.Sunset.UTC().Format(http.TimeFormat))
+	}
+}
+
+// accepted reads the version parameter of the Accept header, `version=2` and `version=v2` both name v2.
+func accepted(accept string) (string, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		_, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || params["version"] == "" {
+			continue
+		}
+
+		name := strings.ToLower(params["version"])
+		if !strings.HasPrefix(name, "v") {
+			name = "v" + name
+		}
+
+		return name, true
+	}
+
+	return "", false
+}
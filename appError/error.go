@@ -12,6 +12,15 @@ type TeqError struct {
 	HTTPCode  int
 	Message   string
 	IsSentry  bool
+	// Fields details the invalid fields of a request, one entry each.
+	Fields []FieldError
+}
+
+// FieldError is one invalid field, Code names the rule it broke.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 func (e TeqError) Error() string {
@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"github.com/labstack/echo/v4"
+	"myapp/customError"
+)
+
+// RequiredAdmin must be chained after RequiredAuth, which stores the admin flag of the current user.
+func (a *CustomMiddleware) RequiredAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		isAdmin, _ := c.Get("is_admin").(bool)
+		if !isAdmin {
+			return customError.ErrNoPermission()
+		}
+
+		return next(c)
+	}
+}
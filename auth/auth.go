@@ -47,6 +47,7 @@ func (a *CustomMiddleware) RequiredAuth(next echo.HandlerFunc) echo.HandlerFunc
 			return customError.ErrUnauthorized(err)
 		}
 		c.Set("user_id", user.ID)
+		c.Set("is_admin", user.IsAdmin)
 		return next(c)
 	}
 }
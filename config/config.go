@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/pkg/errors"
@@ -23,6 +25,44 @@ type Config struct {
 		DBMaxOpenConns int    `envconfig:"DB_MAX_OPEN_CONNS"`
 		CountRetryTx   int    `envconfig:"DB_TX_RETRY_COUNT"`
 	}
+
+	Card struct {
+		TransferTTL            time.Duration `envconfig:"CARD_TRANSFER_TTL" default:"72h"`
+		TransferExpireInterval time.Duration `envconfig:"CARD_TRANSFER_EXPIRE_INTERVAL" default:"1m"`
+		BatchMaxSize           int           `envconfig:"CARD_BATCH_MAX_SIZE" default:"100"`
+		RevisionRetention      int           `envconfig:"CARD_REVISION_RETENTION" default:"50"`
+		TrashRetention         time.Duration `envconfig:"CARD_TRASH_RETENTION" default:"720h"`
+		TrashPurgeInterval     time.Duration `envconfig:"CARD_TRASH_PURGE_INTERVAL" default:"1h"`
+		ImportMaxFileSizeMB    int64         `envconfig:"CARD_IMPORT_MAX_FILE_SIZE_MB" default:"5"`
+		ImportMaxRows          int           `envconfig:"CARD_IMPORT_MAX_ROWS" default:"1000"`
+		ArchiveInterval        time.Duration `envconfig:"CARD_ARCHIVE_INTERVAL" default:"1m"`
+	}
+
+	Storage struct {
+		LocalPath string `envconfig:"STORAGE_LOCAL_PATH" default:"./storage_data"`
+	}
+
+	Attachment struct {
+		MaxFileSizeMB int64         `envconfig:"ATTACHMENT_MAX_FILE_SIZE_MB" default:"10"`
+		UserQuotaMB   int64         `envconfig:"ATTACHMENT_USER_QUOTA_MB" default:"100"`
+		URLTTL        time.Duration `envconfig:"ATTACHMENT_URL_TTL" default:"5m"`
+		SigningSecret string        `envconfig:"ATTACHMENT_SIGNING_SECRET"`
+	}
+
+	Stats struct {
+		Timezone          string        `envconfig:"STATS_TIMEZONE" default:"UTC"`
+		AggregateInterval time.Duration `envconfig:"STATS_AGGREGATE_INTERVAL" default:"10m"`
+	}
+
+	Market struct {
+		FeePercent int `envconfig:"MARKET_FEE_PERCENT" default:"5"`
+	}
+
+	API struct {
+		// Deprecations and Sunsets date the versions on their way out, `v1:2027-01-01`.
+		Deprecations map[string]string `envconfig:"API_DEPRECATIONS"`
+		Sunsets      map[string]string `envconfig:"API_SUNSETS"`
+	}
 }
 
 func init() {
@@ -37,6 +77,16 @@ func init() {
 
 }
 
+// Validate reports the settings the server cannot run without.
+func (c *Config) Validate() error {
+	// an empty key would let anyone sign the attachment download URLs
+	if c.Attachment.SigningSecret == "" {
+		return errors.New("ATTACHMENT_SIGNING_SECRET is required")
+	}
+
+	return nil
+}
+
 func GetConfig() *Config {
 	return config
 }
@@ -1,9 +1,11 @@
 package customError
 
 import (
+	"errors"
 	"fmt"
 	"myapp/appError"
 	"net/http"
+	"strings"
 )
 
 func ErrModelGet(err error, modelName string) appError.TeqError {
@@ -66,6 +68,46 @@ func ErrRequestInvalidParam(param string) appError.TeqError {
 	}
 }
 
+func ErrModelConflict(modelName string) appError.TeqError {
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusConflict,
+		ErrorCode: "10006",
+		Message:   fmt.Sprintf("%s is not in a valid state for this action.", modelName),
+		IsSentry:  false,
+	}
+}
+
+func ErrFileTooLarge() appError.TeqError {
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusRequestEntityTooLarge,
+		ErrorCode: "10007",
+		Message:   "File is too large.",
+		IsSentry:  false,
+	}
+}
+
+func ErrStorageQuotaExceeded() appError.TeqError {
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusForbidden,
+		ErrorCode: "10008",
+		Message:   "Storage quota exceeded.",
+		IsSentry:  false,
+	}
+}
+
+func ErrUnsupportedFileType() appError.TeqError {
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusUnsupportedMediaType,
+		ErrorCode: "10009",
+		Message:   "Unsupported file type.",
+		IsSentry:  false,
+	}
+}
+
 func ErrCommitTransaction(err error) appError.TeqError {
 	return appError.TeqError{
 		Raw:       err,
@@ -96,7 +138,13 @@ func ErrNoPermission() appError.TeqError {
 	}
 }
 
+// ErrInvalidParams keeps the errors which already are a TeqError, such as the failed validations of the binder.
 func ErrInvalidParams(err error) appError.TeqError {
+	var teqErr appError.TeqError
+	if errors.As(err, &teqErr) {
+		return teqErr
+	}
+
 	return appError.TeqError{
 		Raw:       err,
 		HTTPCode:  http.StatusBadRequest,
@@ -165,3 +213,74 @@ func ErrTokenExpired(err error) appError.TeqError {
 		IsSentry:  false,
 	}
 }
+
+func ErrInvalidAttributes(violations []string) appError.TeqError {
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusUnprocessableEntity,
+		ErrorCode: "10010",
+		Message:   "Invalid attributes: " + strings.Join(violations, "; "),
+		IsSentry:  false,
+	}
+}
+
+func ErrUnknownField(field string) appError.TeqError {
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusBadRequest,
+		ErrorCode: "10011",
+		Message:   fmt.Sprintf("Unknown field: `%s`.", field),
+		IsSentry:  false,
+	}
+}
+
+func ErrInvalidFields(fields []appError.FieldError) appError.TeqError {
+	message := "Invalid fields."
+	if len(fields) > 0 {
+		names := make([]string, 0, len(fields))
+		for i := range fields {
+			names = append(names, fields[i].Field)
+		}
+
+		message = fmt.Sprintf("Invalid fields: %s.", strings.Join(names, ", "))
+	}
+
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusUnprocessableEntity,
+		ErrorCode: "10012",
+		Message:   message,
+		IsSentry:  false,
+		Fields:    fields,
+	}
+}
+
+func ErrUnsupportedVersion(version string) appError.TeqError {
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusNotAcceptable,
+		ErrorCode: "10013",
+		Message:   fmt.Sprintf("Unsupported API version: `%s`.", version),
+		IsSentry:  false,
+	}
+}
+
+func ErrPreconditionRequired() appError.TeqError {
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusPreconditionRequired,
+		ErrorCode: "10014",
+		Message:   "The If-Match header is required.",
+		IsSentry:  false,
+	}
+}
+
+func ErrPreconditionFailed() appError.TeqError {
+	return appError.TeqError{
+		Raw:       nil,
+		HTTPCode:  http.StatusPreconditionFailed,
+		ErrorCode: "10015",
+		Message:   "The record changed since it was read.",
+		IsSentry:  false,
+	}
+}
@@ -0,0 +1,46 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	CardArchived         = "card.archived"
+	CardCommentMentioned = "card.comment.mentioned"
+)
+
+type Event struct {
+	Name       string      `json:"name"`
+	Payload    interface{} `json:"payload"`
+	OccurredAt time.Time   `json:"occurred_at"`
+}
+
+// Publisher hands domain events to whoever listens to them.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// NewLog returns a Publisher writing every event as a json line to the service log.
+func NewLog() Publisher {
+	return &logPublisher{}
+}
+
+type logPublisher struct{}
+
+func (l *logPublisher) Publish(ctx context.Context, e Event) error {
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("EVENT: ", string(data))
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package fractional
+
+import (
+	"errors"
+	"strings"
+)
+
+// digits are listed in the order a binary collation compares them.
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var (
+	ErrInvalidKey   = errors.New("fractional: invalid key")
+	ErrInvalidOrder = errors.New("fractional: keys are not in order")
+)
+
+// KeyBetween returns a key which sorts strictly between a and b, so an item can move without renumbering
+// its neighbours. An empty a stands for the start of the list and an empty b for its end.
+func KeyBetween(a string, b string) (string, error) {
+	if (a != "" && !IsValidKey(a)) || (b != "" && !IsValidKey(b)) {
+		return "", ErrInvalidKey
+	}
+
+	if a != "" && b != "" && a >= b {
+		return "", ErrInvalidOrder
+	}
+
+	switch {
+	case a != "" && b == "":
+		return after(a), nil
+	case a == "" && b != "":
+		return before(b), nil
+	}
+
+	return midpoint(a, b), nil
+}
+
+// IsValidKey reports whether key only uses the digits and doesn't end with the smallest one,
+// keys with a trailing zero would leave no room before them.
+func IsValidKey(key string) bool {
+	if key == "" || key[len(key)-1] == digits[0] {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+
+	return digits[0]
+}
+
+func midpoint(a string, b string) string {
+	if b != "" {
+		// keep the common prefix, a is padded with zeros
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	low := 0
+	if a != "" {
+		low = strings.IndexByte(digits, a[0])
+	}
+
+	high := len(digits)
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+
+	if high-low > 1 {
+		return string(digits[(low+high)/2])
+	}
+
+	// the first digits are consecutive
+	if len(b) > 1 {
+		return b[:1]
+	}
+
+	rest := ""
+	if a != "" {
+		rest = a[1:]
+	}
+
+	return string(digits[low]) + midpoint(rest, "")
+}
+
+// after steps the integer head of a up by one instead of bisecting towards the end, so appending only adds
+// a digit once a head runs out. The head is one digit longer than the run of largest digits it follows,
+// a head which would start with the largest digit moves on to a longer run.
+func after(a string) string {
+	run := len(a) - len(strings.TrimLeft(a, digits[len(digits)-1:]))
+	head := []byte(pad(a[run:], run+1))
+
+	if increment(head) && head[0] != digits[len(digits)-1] {
+		return strings.TrimRight(a[:run]+string(head), digits[:1])
+	}
+
+	return strings.Repeat(digits[len(digits)-1:], run+1) + digits[1:2]
+}
+
+// before mirrors after for the start of the list, the head follows a run of zeros and is stepped down.
+func before(b string) string {
+	run := len(b) - len(strings.TrimLeft(b, digits[:1]))
+	head := []byte(pad(b[run:], run+1))
+
+	if decrement(head) && head[0] != digits[0] {
+		return strings.TrimRight(b[:run]+string(head), digits[:1])
+	}
+
+	return strings.Repeat(digits[:1], run+1) + strings.Repeat(digits[len(digits)-1:], run+2)
+}
+
+// pad cuts key to n digits, filling it up with zeros.
+func pad(key string, n int) string {
+	if len(key) >= n {
+		return key[:n]
+	}
+
+	return key + strings.Repeat(digits[:1], n-len(key))
+}
+
+// increment adds one to the number in place, it reports false when the number overflows.
+func increment(number []byte) bool {
+	for i := len(number) - 1; i >= 0; i-- {
+		d := strings.IndexByte(digits, number[i])
+		if d < len(digits)-1 {
+			number[i] = digits[d+1]
+			return true
+		}
+
+		number[i] = digits[0]
+	}
+
+	return false
+}
+
+// decrement subtracts one from the number in place, it reports false when the number underflows.
+func decrement(number []byte) bool {
+	for i := len(number) - 1; i >= 0; i-- {
+		d := strings.IndexByte(digits, number[i])
+		if d > 0 {
+			number[i] = digits[d-1]
+			return true
+		}
+
+		number[i] = digits[len(digits)-1]
+	}
+
+	return false
+}
+
+// EvenKeys returns n ordered keys spread evenly, it is used to rebalance a list whose keys grew too long.
+func EvenKeys(n int) []string {
+	var (
+		base   = int64(len(digits))
+		length = 1
+		space  = base
+	)
+
+	// leave about one digit of room between consecutive keys
+	for space <= int64(n+1)*base {
+		space *= base
+		length++
+	}
+
+	keys := make([]string, 0, n)
+	step := space / int64(n+1)
+
+	for i := 1; i <= n; i++ {
+		value := int64(i) * step
+		key := make([]byte, length)
+
+		for j := length - 1; j >= 0; j-- {
+			key[j] = digits[value%base]
+			value /= base
+		}
+
+		keys = append(keys, strings.TrimRight(string(key), digits[:1]))
+	}
+
+	return keys
+}
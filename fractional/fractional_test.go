@@ -0,0 +1,92 @@
+package fractional
+
+import "testing"
+
+func TestKeyBetweenAppend(t *testing.T) {
+	last := ""
+	for i := 0; i < 100000; i++ {
+		key, err := KeyBetween(last, "")
+		if err != nil {
+			t.Fatalf("append %d after %q: %v", i, last, err)
+		}
+
+		if !IsValidKey(key) || key <= last {
+			t.Fatalf("append %d after %q gave %q", i, last, key)
+		}
+
+		last = key
+	}
+
+	if len(last) > 5 {
+		t.Errorf("key grew to %q after 100000 appends", last)
+	}
+}
+
+func TestKeyBetweenPrepend(t *testing.T) {
+	first := ""
+	for i := 0; i < 100000; i++ {
+		key, err := KeyBetween("", first)
+		if err != nil {
+			t.Fatalf("prepend %d before %q: %v", i, first, err)
+		}
+
+		if !IsValidKey(key) || (first != "" && key >= first) {
+			t.Fatalf("prepend %d before %q gave %q", i, first, key)
+		}
+
+		first = key
+	}
+
+	if len(first) > 7 {
+		t.Errorf("key grew to %q after 100000 prepends", first)
+	}
+}
+
+func TestKeyBetween(t *testing.T) {
+	tests := []struct {
+		a, b string
+		err  error
+	}{
+		{"", "", nil},
+		{"V", "W", nil},
+		{"V", "V1", nil},
+		{"y", "z", nil},
+		{"zz", "", nil},
+		{"", "01", nil},
+		{"", "0001", nil},
+		{"0V3", "", nil},
+		{"W", "V", ErrInvalidOrder},
+		{"V", "V", ErrInvalidOrder},
+		{"V0", "", ErrInvalidKey},
+		{"", "V-", ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		key, err := KeyBetween(tt.a, tt.b)
+		if err != tt.err {
+			t.Errorf("KeyBetween(%q, %q) error = %v, want %v", tt.a, tt.b, err, tt.err)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if !IsValidKey(key) || (tt.a != "" && key <= tt.a) || (tt.b != "" && key >= tt.b) {
+			t.Errorf("KeyBetween(%q, %q) = %q", tt.a, tt.b, key)
+		}
+	}
+}
+
+func TestEvenKeys(t *testing.T) {
+	keys := EvenKeys(5000)
+	for i := range keys {
+		if !IsValidKey(keys[i]) {
+			t.Fatalf("key %d is %q", i, keys[i])
+		}
+
+		if i > 0 && keys[i] <= keys[i-1] {
+			t.Fatalf("key %d is %q after %q", i, keys[i], keys[i-1])
+		}
+	}
+}
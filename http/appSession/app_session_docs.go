@@ -0,0 +1,30 @@
+package appSession
+
+import (
+	"myapp/openapi"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+const tag = "Session"
+
+// Operations describe the session routes for the API documentation.
+var Operations = []openapi.Operation{
+	{
+		Handler:  (*Route).SignUp,
+		Tag:      tag,
+		Summary:  "Create an account",
+		Request:  payload.CreateUserRequest{},
+		Response: presenter.SignUpResponseWrapper{},
+		Public:   true,
+	},
+	{
+		Handler:  (*Route).SignIn,
+		Tag:      tag,
+		Summary:  "Sign in and get a token",
+		Request:  payload.SignInRequest{},
+		Response: presenter.SignUpResponseWrapper{},
+		Public:   true,
+		Errors:   openapi.InvalidParams("username", "password"),
+	},
+}
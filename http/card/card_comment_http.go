@@ -0,0 +1,165 @@
+package card
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/teq"
+)
+
+func (r *Route) CreateComment(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardCommentResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.CreateCardCommentRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.Card.CreateComment(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetComments(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		req    = payload.GetListCardCommentRequest{}
+		resp   *presenter.ListCardCommentResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.Card.GetComments(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetCommentReplies(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		req    = payload.GetListCardCommentRequest{}
+		resp   *presenter.ListCardCommentResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	commentId, err := strconv.ParseInt(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.ParentId = commentId
+	req.UserId = userId
+	resp, err = r.UseCase.Card.GetComments(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) UpdateComment(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardCommentResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	commentId, err := strconv.ParseInt(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.UpdateCardCommentRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.ID = commentId
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.Card.UpdateComment(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) DeleteComment(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	commentId, err := strconv.ParseInt(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	err = r.UseCase.Card.DeleteComment(ctx, &payload.DeleteCardCommentRequest{
+		ID:     commentId,
+		CardId: cardId,
+		UserId: userId,
+	})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, nil)
+}
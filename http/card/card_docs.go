@@ -0,0 +1,316 @@
+package card
+
+import (
+	"myapp/customError"
+	"myapp/openapi"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+const tag = "Cards"
+
+var (
+	errNotFound     = customError.ErrModelNotFound()
+	errNoPermission = customError.ErrNoPermission()
+	errConflict     = customError.ErrModelConflict("Card")
+	errAttributes   = customError.ErrInvalidAttributes([]string{"<violations of the card type schema>"})
+	errList         = openapi.Errors(openapi.InvalidParams("sort", "cursor", "filter"), customError.ErrUnknownField("field"))
+)
+
+const listDescription = "Filters are given as `filter[<field>][<op>]=<value>`, `filter[<field>]` compares with eq. " +
+	"`fields` picks the fields of every row and `include` the relations, which are capped per row."
+
+// Operations describe the card routes for the API documentation.
+var Operations = []openapi.Operation{
+	{
+		Handler:  (*Route).Create,
+		Tag:      tag,
+		Summary:  "Create a card",
+		Request:  payload.CreateCardRequest{},
+		Response: presenter.CardResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("card_type", "template_id", "expires_at"), errAttributes),
+	},
+	{
+		Handler: (*Route).Batch,
+		Tag:     tag,
+		Summary: "Create, update and delete cards at once",
+		Description: "An update with a `version` only applies to that version of the card, as If-Match does, " +
+			"and fails with the precondition error otherwise. Without one it updates any version.",
+		Request:  payload.BatchCardRequest{},
+		Response: presenter.BatchCardResponseWrapper{},
+		Errors:   openapi.InvalidParams("mode", "operations", "op", "id"),
+	},
+	{
+		Handler:     (*Route).GetList,
+		Tag:         tag,
+		Summary:     "List the cards",
+		Description: listDescription + " Attributes are matched with `attr.<path>=<value>`.",
+		Request:     payload.GetListCardRequest{},
+		Response:    presenter.ListCardResponseWrapper{},
+		Errors:      openapi.Errors(errList, openapi.InvalidParams("scope", "tags", "tags_mode", "attributes")),
+	},
+	{
+		Handler:  (*Route).GetTrash,
+		Tag:      tag,
+		Summary:  "List the deleted cards",
+		Request:  payload.GetListCardTrashRequest{},
+		Response: presenter.ListCardResponseWrapper{},
+	},
+	{
+		Handler:  (*Route).Export,
+		Tag:      tag,
+		Summary:  "Export the cards as csv or json",
+		Request:  payload.ExportCardRequest{},
+		Produces: "application/octet-stream",
+		Errors:   openapi.InvalidParams("format"),
+	},
+	{
+		Handler:  (*Route).Import,
+		Tag:      tag,
+		Summary:  "Import cards from a csv or json file",
+		Request:  payload.ImportCardRequest{},
+		Files:    []string{"file"},
+		Response: presenter.ImportCardResponseWrapper{},
+		Errors: openapi.Errors(
+			openapi.InvalidParams("file", "format", "on_duplicate", "name_card", "card_type", "tags"),
+			customError.ErrFileTooLarge(),
+		),
+	},
+	{
+		Handler:   (*Route).GetByID,
+		Tag:       tag,
+		Summary:   "Get a card",
+		Response:  presenter.CardResponseWrapper{},
+		Versioned: true,
+		Errors:    openapi.Errors(errNotFound),
+	},
+	{
+		Handler:   (*Route).Update,
+		Tag:       tag,
+		Summary:   "Update a card",
+		Request:   payload.UpdateCardRequest{},
+		Response:  presenter.CardResponseWrapper{},
+		Versioned: true,
+		Errors: openapi.Errors(
+			openapi.InvalidParams("card_type", "expires_at"),
+			errAttributes, errNotFound, errNoPermission,
+		),
+	},
+	{
+		Handler:    (*Route).Delete,
+		Tag:        tag,
+		Summary:    "Move a card to the trash or delete it for good",
+		Parameters: []openapi.Parameter{{In: "query", Name: "permanent", Type: "boolean"}},
+		Errors:     openapi.Errors(errNotFound, errNoPermission, errConflict),
+	},
+	{
+		Handler:     (*Route).DeleteV2,
+		Tag:         tag,
+		Summary:     "Move a card to the trash or delete it for good",
+		Description: "Answers 204 with no body.",
+		Parameters:  []openapi.Parameter{{In: "query", Name: "permanent", Type: "boolean"}},
+		NoContent:   true,
+		Errors:      openapi.Errors(errNotFound, errNoPermission, errConflict),
+	},
+	{
+		Handler:  (*Route).Restore,
+		Tag:      tag,
+		Summary:  "Restore a card from the trash",
+		Response: presenter.CardResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).Duplicate,
+		Tag:      tag,
+		Summary:  "Duplicate a card",
+		Request:  payload.DuplicateCardRequest{},
+		Response: presenter.CardResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("name_card"), errNotFound),
+	},
+	{
+		Handler:  (*Route).CreateTemplate,
+		Tag:      tag,
+		Summary:  "Save a card as template",
+		Request:  payload.CreateCardTemplateRequest{},
+		Response: presenter.CardTemplateResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("name"), errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).CreateTransfer,
+		Tag:      tag,
+		Summary:  "Offer a card to another user",
+		Request:  payload.CreateCardTransferRequest{},
+		Response: presenter.CardTransferResponseWrapper{},
+		Errors: openapi.Errors(
+			openapi.InvalidParams("to_user_id"),
+			errNotFound, errConflict, customError.ErrModelConflict("CardTransfer"),
+		),
+	},
+	{
+		Handler:  (*Route).CreateListing,
+		Tag:      tag,
+		Summary:  "List a card on the market",
+		Request:  payload.CreateCardListingRequest{},
+		Response: presenter.CardListingResponseWrapper{},
+		Errors: openapi.Errors(
+			openapi.InvalidParams("price"),
+			errNotFound, errConflict, customError.ErrModelConflict("CardListing"),
+		),
+	},
+	{
+		Handler:  (*Route).Share,
+		Tag:      tag,
+		Summary:  "Share a card with a user",
+		Request:  payload.ShareCardRequest{},
+		Response: presenter.CardShareResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("user_id", "permission"), errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).GetShares,
+		Tag:      tag,
+		Summary:  "List the shares of a card",
+		Response: presenter.ListCardShareResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler: (*Route).RevokeShare,
+		Tag:     tag,
+		Summary: "Stop sharing a card with a user",
+		Errors:  openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).AttachTags,
+		Tag:      tag,
+		Summary:  "Tag a card",
+		Request:  payload.AttachCardTagsRequest{},
+		Response: presenter.CardResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("tags"), errNotFound, errNoPermission, errConflict),
+	},
+	{
+		Handler:  (*Route).DetachTag,
+		Tag:      tag,
+		Summary:  "Remove a tag from a card",
+		Response: presenter.CardResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errNoPermission, errConflict),
+	},
+	{
+		Handler:  (*Route).UploadAttachment,
+		Tag:      tag,
+		Summary:  "Attach a file to a card",
+		Files:    []string{"file"},
+		Response: presenter.CardAttachmentResponseWrapper{},
+		Errors: openapi.Errors(
+			openapi.InvalidParams("file"),
+			errNotFound, errNoPermission, errConflict,
+			customError.ErrFileTooLarge(), customError.ErrStorageQuotaExceeded(), customError.ErrUnsupportedFileType(),
+		),
+	},
+	{
+		Handler:  (*Route).GetAttachments,
+		Tag:      tag,
+		Summary:  "List the attachments of a card",
+		Response: presenter.ListCardAttachmentResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler: (*Route).DeleteAttachment,
+		Tag:     tag,
+		Summary: "Delete an attachment",
+		Errors:  openapi.Errors(errNotFound, errNoPermission, errConflict),
+	},
+	{
+		Handler:     (*Route).DownloadAttachment,
+		Tag:         tag,
+		Summary:     "Download an attachment",
+		Description: "The link is signed, it is handed out with the attachment and expires.",
+		Request:     payload.DownloadCardAttachmentRequest{},
+		Produces:    "application/octet-stream",
+		Public:      true,
+		Errors:      openapi.Errors(errNotFound, errNoPermission, customError.ErrNotFound(nil)),
+	},
+	{
+		Handler:  (*Route).GetRevisions,
+		Tag:      tag,
+		Summary:  "List the revisions of a card",
+		Request:  payload.GetListCardRevisionRequest{},
+		Response: presenter.ListCardRevisionResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).DiffRevisions,
+		Tag:      tag,
+		Summary:  "Compare two revisions of a card",
+		Request:  payload.DiffCardRevisionRequest{},
+		Response: presenter.CardRevisionDiffResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("from"), errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).RestoreRevision,
+		Tag:      tag,
+		Summary:  "Restore a revision of a card",
+		Response: presenter.CardResponseWrapper{},
+		Errors: openapi.Errors(
+			openapi.InvalidParams("card_type"),
+			errNotFound, errNoPermission, customError.ErrPreconditionFailed(),
+		),
+	},
+	{
+		Handler:  (*Route).CreateComment,
+		Tag:      tag,
+		Summary:  "Comment on a card",
+		Request:  payload.CreateCardCommentRequest{},
+		Response: presenter.CardCommentResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("body", "parent_id"), errNotFound),
+	},
+	{
+		Handler:  (*Route).GetComments,
+		Tag:      tag,
+		Summary:  "List the comments of a card",
+		Request:  payload.GetListCardCommentRequest{},
+		Response: presenter.ListCardCommentResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound),
+	},
+	{
+		Handler:  (*Route).GetCommentReplies,
+		Tag:      tag,
+		Summary:  "List the replies to a comment",
+		Request:  payload.GetListCardCommentRequest{},
+		Response: presenter.ListCardCommentResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound),
+	},
+	{
+		Handler:  (*Route).UpdateComment,
+		Tag:      tag,
+		Summary:  "Edit a comment",
+		Request:  payload.UpdateCardCommentRequest{},
+		Response: presenter.CardCommentResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("body"), errNotFound, errNoPermission),
+	},
+	{
+		Handler: (*Route).DeleteComment,
+		Tag:     tag,
+		Summary: "Delete a comment and its replies",
+		Errors:  openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).GetTemplates,
+		Tag:      "Card templates",
+		Summary:  "List the card templates",
+		Request:  payload.GetListCardTemplateRequest{},
+		Response: presenter.ListCardTemplateResponseWrapper{},
+		Errors:   openapi.InvalidParams("scope"),
+	},
+	{
+		Handler:  (*Route).GetTemplate,
+		Tag:      "Card templates",
+		Summary:  "Get a card template",
+		Response: presenter.CardTemplateResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound),
+	},
+	{
+		Handler: (*Route).DeleteTemplate,
+		Tag:     "Card templates",
+		Summary: "Delete a card template",
+		Errors:  openapi.Errors(errNotFound, errNoPermission),
+	},
+}
@@ -1,6 +1,11 @@
 package card
 
 import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
 	"github.com/labstack/echo/v4"
 	"myapp/appError"
 	"myapp/customError"
@@ -18,10 +23,47 @@ type Route struct {
 func Init(group *echo.Group, useCase *usecase.UseCase) {
 	r := &Route{UseCase: useCase}
 	group.POST("", r.Create)
+	group.POST("/batch", r.Batch)
 	group.GET("", r.GetList)
+	group.GET("/trash", r.GetTrash)
+	group.GET("/export", r.Export)
+	group.POST("/import", r.Import)
 	group.GET("/:id", r.GetByID)
 	group.PUT("/:id", r.Update)
 	group.DELETE("/:id", r.Delete)
+	group.POST("/:id/restore", r.Restore)
+	group.POST("/:id/duplicate", r.Duplicate)
+	group.POST("/:id/templates", r.CreateTemplate)
+	group.POST("/:id/transfers", r.CreateTransfer)
+	group.POST("/:id/listings", r.CreateListing)
+	group.POST("/:id/shares", r.Share)
+	group.GET("/:id/shares", r.GetShares)
+	group.DELETE("/:id/shares/:user_id", r.RevokeShare)
+	group.POST("/:id/tags", r.AttachTags)
+	group.DELETE("/:id/tags/:tag_id", r.DetachTag)
+	group.POST("/:id/attachments", r.UploadAttachment)
+	group.GET("/:id/attachments", r.GetAttachments)
+	group.DELETE("/:id/attachments/:attachment_id", r.DeleteAttachment)
+	group.GET("/:id/revisions", r.GetRevisions)
+	group.GET("/:id/revisions/diff", r.DiffRevisions)
+	group.POST("/:id/revisions/:rev/restore", r.RestoreRevision)
+	group.POST("/:id/comments", r.CreateComment)
+	group.GET("/:id/comments", r.GetComments)
+	group.GET("/:id/comments/:comment_id/replies", r.GetCommentReplies)
+	group.PUT("/:id/comments/:comment_id", r.UpdateComment)
+	group.DELETE("/:id/comments/:comment_id", r.DeleteComment)
+}
+
+// InitV2 registers the card routes which changed in v2, v1 serves the others.
+func InitV2(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.DELETE("/:id", r.DeleteV2)
+}
+
+// InitAttachment registers the signed download route, it must stay outside of the authenticated group.
+func InitAttachment(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.GET("/:id/download", r.DownloadAttachment)
 }
 
 func (r *Route) Create(c echo.Context) error {
@@ -32,11 +74,11 @@ func (r *Route) Create(c echo.Context) error {
 		userId = c.Get("user_id").(int64)
 	)
 
-	req.UserId = userId
 	if err := c.Bind(&req); err != nil {
 		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
 	}
 
+	req.UserId = userId
 	resp, err := r.UseCase.Card.Create(ctx, &req)
 	if err != nil {
 		return teq.Response.Error(c, err.(appError.TeqError))
@@ -45,7 +87,45 @@ func (r *Route) Create(c echo.Context) error {
 	return teq.Response.Success(c, resp)
 }
 
+func (r *Route) Batch(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		resp   *presenter.BatchCardResponseWrapper
+		req    = payload.BatchCardRequest{}
+		userId = c.Get("user_id").(int64)
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.UserId = userId
+	resp, err := r.UseCase.Card.Batch(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
 func (r *Route) Delete(c echo.Context) error {
+	if err := r.delete(c); err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, nil)
+}
+
+// DeleteV2 answers with no content instead of the empty envelope of v1.
+func (r *Route) DeleteV2(c echo.Context) error {
+	if err := r.delete(c); err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (r *Route) delete(c echo.Context) error {
 	var (
 		ctx   = &teq.CustomEchoContext{Context: c}
 		idStr = c.Param("id")
@@ -53,29 +133,155 @@ func (r *Route) Delete(c echo.Context) error {
 
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
+		return customError.ErrInvalidParams(err)
+	}
+
+	req := payload.DeleteRequest{ID: id}
+	if permanent := c.QueryParam("permanent"); permanent != "" {
+		req.Permanent, err = strconv.ParseBool(permanent)
+		if err != nil {
+			return customError.ErrInvalidParams(err)
+		}
+	}
+
+	return r.UseCase.Card.Delete(ctx, &req)
+}
+
+func (r *Route) GetList(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		req    = payload.GetListCardRequest{}
+		resp   *presenter.ListCardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	if err := c.Bind(&req); err != nil {
 		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
 	}
 
-	err = r.UseCase.Card.Delete(ctx, &payload.DeleteRequest{ID: id})
+	req.UserId = userId
+	req.Attributes = attributeFilters(c.QueryParams())
+	req.Filters = payload.FilterParams(c.QueryParams())
+	req.IncludeSet = c.QueryParams().Has("include")
+
+	resp, err := r.UseCase.Card.GetList(ctx, &req)
 	if err != nil {
 		return teq.Response.Error(c, err.(appError.TeqError))
 	}
 
-	return teq.Response.Success(c, nil)
+	return teq.Response.Success(c, resp)
 }
 
-func (r *Route) GetList(c echo.Context) error {
+// attributeFilters collects the `attr.<path>` query parameters keyed by path.
+func attributeFilters(params url.Values) map[string][]string {
+	filters := make(map[string][]string)
+	for key, values := range params {
+		if strings.HasPrefix(key, "attr.") {
+			filters[strings.TrimPrefix(key, "attr.")] = values
+		}
+	}
+
+	return filters
+}
+
+func (r *Route) GetTrash(c echo.Context) error {
 	var (
-		ctx  = &teq.CustomEchoContext{Context: c}
-		req  = payload.GetListRequest{}
-		resp *presenter.ListCardResponseWrapper
+		ctx    = &teq.CustomEchoContext{Context: c}
+		req    = payload.GetListCardTrashRequest{}
+		resp   *presenter.ListCardResponseWrapper
+		userId = c.Get("user_id").(int64)
 	)
 
 	if err := c.Bind(&req); err != nil {
 		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
 	}
 
-	resp, err := r.UseCase.Card.GetList(ctx, &req)
+	req.UserId = userId
+
+	resp, err := r.UseCase.Card.GetTrash(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) Export(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		req    = payload.ExportCardRequest{}
+		userId = c.Get("user_id").(int64)
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.UserId = userId
+
+	resp, err := r.UseCase.Card.Export(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, resp.ContentType)
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", resp.FileName),
+	)
+	c.Response().WriteHeader(http.StatusOK)
+
+	// the status is already sent, a failure can only cut the stream short
+	return resp.Write(c.Response())
+}
+
+func (r *Route) Import(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		req    = payload.ImportCardRequest{}
+		resp   *presenter.ImportCardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	// the options may come as form fields next to the file or in the query string
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.File = file
+	req.UserId = userId
+
+	resp, err = r.UseCase.Card.Import(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) Restore(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.Card.Restore(ctx, &payload.RestoreCardRequest{ID: cardId, UserId: userId})
 	if err != nil {
 		return teq.Response.Error(c, err.(appError.TeqError))
 	}
@@ -96,20 +302,27 @@ func (r *Route) Update(c echo.Context) error {
 		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
 	}
 
-	req := payload.UpdateCardRequest{
-		ID:     cardId,
-		UserId: userId,
+	version, err := teq.IfMatch(c)
+	if err != nil {
+		return teq.Response.Error(ctx, err.(appError.TeqError))
 	}
 
+	req := payload.UpdateCardRequest{}
 	if err = c.Bind(&req); err != nil {
 		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
 	}
 
+	req.ID = cardId
+	req.UserId = userId
+	req.Version = version
+
 	resp, err = r.UseCase.Card.Update(ctx, &req)
 	if err != nil {
 		return teq.Response.Error(c, err.(appError.TeqError))
 	}
 
+	c.Response().Header().Set("ETag", teq.ETag(resp.Card.Version))
+
 	return teq.Response.Success(c, resp)
 }
 
@@ -130,5 +343,394 @@ func (r *Route) GetByID(c echo.Context) error {
 		return teq.Response.Error(c, err.(appError.TeqError))
 	}
 
+	return teq.Response.Versioned(c, resp.Card.Version, resp)
+}
+
+func (r *Route) CreateTransfer(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardTransferResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.CreateCardTransferRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.CardTransfer.Create(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) CreateListing(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardListingResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.CreateCardListingRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.CardListing.Create(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) Share(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardShareResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.ShareCardRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.CardShare.Share(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetShares(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.ListCardShareResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.CardShare.GetList(ctx, &payload.GetListCardShareRequest{CardId: cardId, UserId: userId})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) RevokeShare(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	targetUserId, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	err = r.UseCase.CardShare.Revoke(ctx, &payload.RevokeCardShareRequest{
+		CardId:       cardId,
+		TargetUserId: targetUserId,
+		UserId:       userId,
+	})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, nil)
+}
+
+func (r *Route) AttachTags(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.AttachCardTagsRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.Card.AttachTags(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) DetachTag(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	tagId, err := strconv.ParseInt(c.Param("tag_id"), 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.Card.DetachTag(ctx, &payload.DetachCardTagRequest{
+		CardId: cardId,
+		TagId:  tagId,
+		UserId: userId,
+	})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) UploadAttachment(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardAttachmentResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.Card.UploadAttachment(ctx, &payload.UploadCardAttachmentRequest{
+		CardId: cardId,
+		File:   file,
+		UserId: userId,
+	})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetAttachments(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.ListCardAttachmentResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.Card.GetAttachments(ctx, &payload.CardAttachmentRequest{CardId: cardId, UserId: userId})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) DeleteAttachment(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	attachmentId, err := strconv.ParseInt(c.Param("attachment_id"), 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	err = r.UseCase.Card.DeleteAttachment(ctx, &payload.CardAttachmentRequest{
+		CardId:       cardId,
+		AttachmentId: attachmentId,
+		UserId:       userId,
+	})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, nil)
+}
+
+func (r *Route) DownloadAttachment(c echo.Context) error {
+	var (
+		ctx   = &teq.CustomEchoContext{Context: c}
+		idStr = c.Param("id")
+		req   = payload.DownloadCardAttachmentRequest{}
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.ID = id
+	resp, err := r.UseCase.Card.DownloadAttachment(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+	defer resp.Content.Close()
+
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", resp.Attachment.FileName),
+	)
+
+	return c.Stream(http.StatusOK, resp.Attachment.ContentType, resp.Content)
+}
+
+func (r *Route) GetRevisions(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		req    = payload.GetListCardRevisionRequest{}
+		resp   *presenter.ListCardRevisionResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.Card.GetRevisions(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) DiffRevisions(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		req    = payload.DiffCardRevisionRequest{}
+		resp   *presenter.CardRevisionDiffResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.Card.DiffRevisions(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) RestoreRevision(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.Card.RestoreRevision(ctx, &payload.RestoreCardRevisionRequest{
+		CardId:   cardId,
+		Revision: revision,
+		UserId:   userId,
+	})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
 	return teq.Response.Success(c, resp)
 }
@@ -0,0 +1,138 @@
+package card
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/teq"
+	"myapp/usecase"
+)
+
+// InitTemplate registers the template routes, global templates are listed to every user.
+func InitTemplate(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.GET("", r.GetTemplates)
+	group.GET("/:id", r.GetTemplate)
+	group.DELETE("/:id", r.DeleteTemplate)
+}
+
+func (r *Route) Duplicate(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.DuplicateCardRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.ID = id
+	req.UserId = userId
+	resp, err = r.UseCase.Card.Duplicate(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) CreateTemplate(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.CardTemplateResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	cardId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.CreateCardTemplateRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.Card.CreateTemplate(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetTemplates(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		req    = payload.GetListCardTemplateRequest{}
+		resp   *presenter.ListCardTemplateResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.UserId = userId
+
+	resp, err := r.UseCase.Card.GetTemplates(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetTemplate(c echo.Context) error {
+	var (
+		ctx   = &teq.CustomEchoContext{Context: c}
+		idStr = c.Param("id")
+		resp  *presenter.CardTemplateResponseWrapper
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.Card.GetTemplate(ctx, &payload.GetByIDRequest{ID: id})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) DeleteTemplate(c echo.Context) error {
+	var (
+		ctx   = &teq.CustomEchoContext{Context: c}
+		idStr = c.Param("id")
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	err = r.UseCase.Card.DeleteTemplate(ctx, &payload.DeleteRequest{ID: id})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, nil)
+}
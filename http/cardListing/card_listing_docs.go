@@ -0,0 +1,58 @@
+package cardListing
+
+import (
+	"myapp/customError"
+	"myapp/openapi"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+const tag = "Market"
+
+var (
+	errNotFound = customError.ErrModelNotFound()
+	errConflict = customError.ErrModelConflict("CardListing")
+)
+
+// Operations describe the market routes for the API documentation.
+var Operations = []openapi.Operation{
+	{
+		Handler:  (*Route).GetList,
+		Tag:      tag,
+		Summary:  "List the cards for sale",
+		Request:  payload.GetListCardListingRequest{},
+		Response: presenter.ListCardListingResponseWrapper{},
+	},
+	{
+		Handler:  (*Route).GetHistory,
+		Tag:      tag,
+		Summary:  "List the sales and purchases of the signed in user",
+		Request:  payload.GetListCardListingRequest{},
+		Response: presenter.ListCardListingResponseWrapper{},
+		Errors:   openapi.InvalidParams("role"),
+	},
+	{
+		Handler:  (*Route).GetByID,
+		Tag:      tag,
+		Summary:  "Get a listing",
+		Response: presenter.CardListingResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound),
+	},
+	{
+		Handler:  (*Route).Buy,
+		Tag:      tag,
+		Summary:  "Buy a listed card",
+		Response: presenter.CardListingResponseWrapper{},
+		Errors: openapi.Errors(
+			openapi.InvalidParams("id", "score"),
+			errNotFound, errConflict, customError.ErrModelConflict("Card"),
+		),
+	},
+	{
+		Handler:  (*Route).Cancel,
+		Tag:      tag,
+		Summary:  "Take a card off the market",
+		Response: presenter.CardListingResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errConflict, customError.ErrNoPermission()),
+	},
+}
@@ -0,0 +1,93 @@
+package cardListing
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/teq"
+	"myapp/usecase"
+	"strconv"
+)
+
+type Route struct {
+	UseCase *usecase.UseCase
+}
+
+func Init(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.GET("", r.GetList)
+	group.GET("/history", r.GetHistory)
+	group.GET("/:id", r.GetByID)
+	group.POST("/:id/buy", r.Buy)
+	group.DELETE("/:id", r.Cancel)
+}
+
+func (r *Route) GetList(c echo.Context) error {
+	return r.list(c, r.UseCase.CardListing.GetList)
+}
+
+func (r *Route) GetHistory(c echo.Context) error {
+	return r.list(c, r.UseCase.CardListing.GetHistory)
+}
+
+func (r *Route) GetByID(c echo.Context) error {
+	return r.action(c, r.UseCase.CardListing.GetByID)
+}
+
+func (r *Route) Buy(c echo.Context) error {
+	return r.action(c, r.UseCase.CardListing.Buy)
+}
+
+func (r *Route) Cancel(c echo.Context) error {
+	return r.action(c, r.UseCase.CardListing.Cancel)
+}
+
+func (r *Route) list(
+	c echo.Context,
+	getList func(ctx context.Context, req *payload.GetListCardListingRequest) (*presenter.ListCardListingResponseWrapper, error),
+) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		req    = payload.GetListCardListingRequest{}
+		userId = c.Get("user_id").(int64)
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.UserId = userId
+	resp, err := getList(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) action(
+	c echo.Context,
+	action func(ctx context.Context, req *payload.CardListingActionRequest) (*presenter.CardListingResponseWrapper, error),
+) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		userId = c.Get("user_id").(int64)
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err := action(ctx, &payload.CardListingActionRequest{ID: id, UserId: userId})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
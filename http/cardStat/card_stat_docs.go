@@ -0,0 +1,32 @@
+package cardStat
+
+import (
+	"myapp/customError"
+	"myapp/openapi"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+const tag = "Stats"
+
+var errStats = openapi.InvalidParams("interval", "from", "to")
+
+// Operations describe the stats routes for the API documentation.
+var Operations = []openapi.Operation{
+	{
+		Handler:  (*Route).GetStats,
+		Tag:      tag,
+		Summary:  "Card stats of the signed in user",
+		Request:  payload.GetCardStatsRequest{},
+		Response: presenter.CardStatsResponseWrapper{},
+		Errors:   errStats,
+	},
+	{
+		Handler:  (*Route).GetAdminStats,
+		Tag:      tag,
+		Summary:  "Card stats of every user or of one",
+		Request:  payload.GetCardStatsRequest{},
+		Response: presenter.CardStatsResponseWrapper{},
+		Errors:   openapi.Errors(errStats, customError.ErrNoPermission()),
+	},
+}
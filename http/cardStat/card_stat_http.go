@@ -0,0 +1,66 @@
+package cardStat
+
+import (
+	"github.com/labstack/echo/v4"
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/teq"
+	"myapp/usecase"
+)
+
+type Route struct {
+	UseCase *usecase.UseCase
+}
+
+func Init(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.GET("", r.GetStats)
+}
+
+func InitAdmin(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.GET("", r.GetAdminStats)
+}
+
+func (r *Route) GetStats(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		req    = payload.GetCardStatsRequest{}
+		resp   *presenter.CardStatsResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.UserId = userId
+
+	resp, err := r.UseCase.CardStat.GetStats(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetAdminStats(c echo.Context) error {
+	var (
+		ctx  = &teq.CustomEchoContext{Context: c}
+		req  = payload.GetCardStatsRequest{}
+		resp *presenter.CardStatsResponseWrapper
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err := r.UseCase.CardStat.GetStats(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
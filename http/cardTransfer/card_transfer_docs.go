@@ -0,0 +1,57 @@
+package cardTransfer
+
+import (
+	"myapp/customError"
+	"myapp/openapi"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+const tag = "Transfers"
+
+// respondErrors are the errors of the routes answering a transfer.
+var respondErrors = openapi.Errors(
+	customError.ErrModelNotFound(),
+	customError.ErrNoPermission(),
+	customError.ErrModelConflict("CardTransfer"),
+)
+
+// Operations describe the transfer routes for the API documentation.
+var Operations = []openapi.Operation{
+	{
+		Handler:  (*Route).GetList,
+		Tag:      tag,
+		Summary:  "List the transfers of the signed in user",
+		Request:  payload.GetListCardTransferRequest{},
+		Response: presenter.ListCardTransferResponseWrapper{},
+		Errors:   openapi.InvalidParams("direction"),
+	},
+	{
+		Handler:  (*Route).GetByID,
+		Tag:      tag,
+		Summary:  "Get a transfer",
+		Response: presenter.CardTransferResponseWrapper{},
+		Errors:   openapi.Errors(customError.ErrModelNotFound()),
+	},
+	{
+		Handler:  (*Route).Accept,
+		Tag:      tag,
+		Summary:  "Accept a transfer",
+		Response: presenter.CardTransferResponseWrapper{},
+		Errors:   openapi.Errors(respondErrors, customError.ErrModelConflict("Card")),
+	},
+	{
+		Handler:  (*Route).Decline,
+		Tag:      tag,
+		Summary:  "Decline a transfer",
+		Response: presenter.CardTransferResponseWrapper{},
+		Errors:   respondErrors,
+	},
+	{
+		Handler:  (*Route).Cancel,
+		Tag:      tag,
+		Summary:  "Cancel a transfer",
+		Response: presenter.CardTransferResponseWrapper{},
+		Errors:   respondErrors,
+	},
+}
@@ -0,0 +1,87 @@
+package cardTransfer
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/teq"
+	"myapp/usecase"
+	"strconv"
+)
+
+type Route struct {
+	UseCase *usecase.UseCase
+}
+
+func Init(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.GET("", r.GetList)
+	group.GET("/:id", r.GetByID)
+	group.POST("/:id/accept", r.Accept)
+	group.POST("/:id/decline", r.Decline)
+	group.POST("/:id/cancel", r.Cancel)
+}
+
+func (r *Route) GetList(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		req    = payload.GetListCardTransferRequest{}
+		resp   *presenter.ListCardTransferResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.UserId = userId
+	resp, err := r.UseCase.CardTransfer.GetList(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetByID(c echo.Context) error {
+	return r.respond(c, r.UseCase.CardTransfer.GetByID)
+}
+
+func (r *Route) Accept(c echo.Context) error {
+	return r.respond(c, r.UseCase.CardTransfer.Accept)
+}
+
+func (r *Route) Decline(c echo.Context) error {
+	return r.respond(c, r.UseCase.CardTransfer.Decline)
+}
+
+func (r *Route) Cancel(c echo.Context) error {
+	return r.respond(c, r.UseCase.CardTransfer.Cancel)
+}
+
+func (r *Route) respond(
+	c echo.Context,
+	action func(ctx context.Context, req *payload.RespondCardTransferRequest) (*presenter.CardTransferResponseWrapper, error),
+) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		userId = c.Get("user_id").(int64)
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err := action(ctx, &payload.RespondCardTransferRequest{ID: id, UserId: userId})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
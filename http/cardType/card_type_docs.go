@@ -0,0 +1,68 @@
+package cardType
+
+import (
+	"myapp/customError"
+	"myapp/openapi"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+const tag = "Card types"
+
+var (
+	errNotFound     = customError.ErrModelNotFound()
+	errNoPermission = customError.ErrNoPermission()
+	errConflict     = customError.ErrModelConflict("CardType")
+)
+
+// Operations describe the card type routes for the API documentation, all but the active list are for admins.
+var Operations = []openapi.Operation{
+	{
+		Handler:  (*Route).GetActiveList,
+		Tag:      tag,
+		Summary:  "List the active card types",
+		Request:  payload.GetListRequest{},
+		Response: presenter.ListCardTypeResponseWrapper{},
+		Public:   true,
+	},
+	{
+		Handler:  (*Route).Create,
+		Tag:      tag,
+		Summary:  "Create a card type",
+		Request:  payload.CreateCardTypeRequest{},
+		Response: presenter.CardTypeResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("code", "name", "attributes_schema"), errNoPermission),
+	},
+	{
+		Handler:  (*Route).GetList,
+		Tag:      tag,
+		Summary:  "List the card types",
+		Request:  payload.GetListRequest{},
+		Response: presenter.ListCardTypeResponseWrapper{},
+		Errors:   openapi.Errors(errNoPermission),
+	},
+	{
+		Handler:  (*Route).GetByID,
+		Tag:      tag,
+		Summary:  "Get a card type",
+		Response: presenter.CardTypeResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).Update,
+		Tag:      tag,
+		Summary:  "Update a card type",
+		Request:  payload.UpdateCardTypeRequest{},
+		Response: presenter.CardTypeResponseWrapper{},
+		Errors: openapi.Errors(
+			openapi.InvalidParams("code", "name", "attributes_schema"),
+			errNotFound, errNoPermission, errConflict,
+		),
+	},
+	{
+		Handler: (*Route).Delete,
+		Tag:     tag,
+		Summary: "Delete a card type",
+		Errors:  openapi.Errors(errNotFound, errNoPermission, errConflict),
+	},
+}
@@ -0,0 +1,151 @@
+package cardType
+
+import (
+	"github.com/labstack/echo/v4"
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/teq"
+	"myapp/usecase"
+	"strconv"
+)
+
+type Route struct {
+	UseCase *usecase.UseCase
+}
+
+func Init(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.GET("", r.GetActiveList)
+}
+
+func InitAdmin(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.POST("", r.Create)
+	group.GET("", r.GetList)
+	group.GET("/:id", r.GetByID)
+	group.PUT("/:id", r.Update)
+	group.DELETE("/:id", r.Delete)
+}
+
+func (r *Route) Create(c echo.Context) error {
+	var (
+		ctx  = &teq.CustomEchoContext{Context: c}
+		resp *presenter.CardTypeResponseWrapper
+		req  = payload.CreateCardTypeRequest{}
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err := r.UseCase.CardType.Create(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) Delete(c echo.Context) error {
+	var (
+		ctx   = &teq.CustomEchoContext{Context: c}
+		idStr = c.Param("id")
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	err = r.UseCase.CardType.Delete(ctx, &payload.DeleteRequest{ID: id})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, nil)
+}
+
+func (r *Route) GetList(c echo.Context) error {
+	var (
+		ctx  = &teq.CustomEchoContext{Context: c}
+		req  = payload.GetListRequest{}
+		resp *presenter.ListCardTypeResponseWrapper
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err := r.UseCase.CardType.GetList(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetActiveList(c echo.Context) error {
+	var (
+		ctx  = &teq.CustomEchoContext{Context: c}
+		req  = payload.GetListRequest{}
+		resp *presenter.ListCardTypeResponseWrapper
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err := r.UseCase.CardType.GetActiveList(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) Update(c echo.Context) error {
+	var (
+		ctx   = &teq.CustomEchoContext{Context: c}
+		idStr = c.Param("id")
+		resp  *presenter.CardTypeResponseWrapper
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.UpdateCardTypeRequest{ID: id}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.CardType.Update(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetByID(c echo.Context) error {
+	var (
+		ctx   = &teq.CustomEchoContext{Context: c}
+		idStr = c.Param("id")
+		resp  *presenter.CardTypeResponseWrapper
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.CardType.GetByID(ctx, &payload.GetByIDRequest{ID: id})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
@@ -0,0 +1,113 @@
+package deck
+
+import (
+	"myapp/customError"
+	"myapp/openapi"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+const tag = "Decks"
+
+var (
+	errNotFound     = customError.ErrModelNotFound()
+	errNoPermission = customError.ErrNoPermission()
+)
+
+// Operations describe the deck routes for the API documentation.
+var Operations = []openapi.Operation{
+	{
+		Handler:  (*Route).Create,
+		Tag:      tag,
+		Summary:  "Create a deck",
+		Request:  payload.CreateDeckRequest{},
+		Response: presenter.DeckResponseWrapper{},
+		Errors:   openapi.InvalidParams("name"),
+	},
+	{
+		Handler:  (*Route).GetList,
+		Tag:      tag,
+		Summary:  "List the decks",
+		Request:  payload.GetListDeckRequest{},
+		Response: presenter.ListDeckResponseWrapper{},
+		Errors:   openapi.InvalidParams("scope"),
+	},
+	{
+		Handler:  (*Route).GetByID,
+		Tag:      tag,
+		Summary:  "Get a deck",
+		Response: presenter.DeckResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).Update,
+		Tag:      tag,
+		Summary:  "Update a deck",
+		Request:  payload.UpdateDeckRequest{},
+		Response: presenter.DeckResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("name"), errNotFound, errNoPermission),
+	},
+	{
+		Handler: (*Route).Delete,
+		Tag:     tag,
+		Summary: "Delete a deck",
+		Errors:  openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).GetCards,
+		Tag:      tag,
+		Summary:  "List the cards of a deck in order",
+		Request:  payload.GetListDeckCardRequest{},
+		Response: presenter.ListDeckCardResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).AddCard,
+		Tag:      tag,
+		Summary:  "Add a card to a deck",
+		Request:  payload.AddDeckCardRequest{},
+		Response: presenter.DeckCardResponseWrapper{},
+		Errors: openapi.Errors(
+			openapi.InvalidParams("card_id", "before_card_id", "after_card_id"),
+			errNotFound, errNoPermission, customError.ErrModelConflict("DeckCard"),
+		),
+	},
+	{
+		Handler:  (*Route).MoveCard,
+		Tag:      tag,
+		Summary:  "Move a card within a deck",
+		Request:  payload.MoveDeckCardRequest{},
+		Response: presenter.DeckCardResponseWrapper{},
+		Errors: openapi.Errors(
+			openapi.InvalidParams("before_card_id", "after_card_id"),
+			errNotFound, errNoPermission, customError.ErrModelConflict("DeckCard"),
+		),
+	},
+	{
+		Handler: (*Route).RemoveCard,
+		Tag:     tag,
+		Summary: "Remove a card from a deck",
+		Errors:  openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).Share,
+		Tag:      tag,
+		Summary:  "Share a deck with a user",
+		Request:  payload.ShareDeckRequest{},
+		Response: presenter.DeckShareResponseWrapper{},
+		Errors:   openapi.Errors(openapi.InvalidParams("user_id", "permission"), errNotFound, errNoPermission),
+	},
+	{
+		Handler:  (*Route).GetShares,
+		Tag:      tag,
+		Summary:  "List the shares of a deck",
+		Response: presenter.ListDeckShareResponseWrapper{},
+		Errors:   openapi.Errors(errNotFound, errNoPermission),
+	},
+	{
+		Handler: (*Route).RevokeShare,
+		Tag:     tag,
+		Summary: "Stop sharing a deck with a user",
+		Errors:  openapi.Errors(errNotFound, errNoPermission),
+	},
+}
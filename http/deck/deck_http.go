@@ -0,0 +1,339 @@
+package deck
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/teq"
+	"myapp/usecase"
+)
+
+type Route struct {
+	UseCase *usecase.UseCase
+}
+
+func Init(group *echo.Group, useCase *usecase.UseCase) {
+	r := &Route{UseCase: useCase}
+	group.POST("", r.Create)
+	group.GET("", r.GetList)
+	group.GET("/:id", r.GetByID)
+	group.PUT("/:id", r.Update)
+	group.DELETE("/:id", r.Delete)
+	group.GET("/:id/cards", r.GetCards)
+	group.POST("/:id/cards", r.AddCard)
+	group.PUT("/:id/cards/:card_id/position", r.MoveCard)
+	group.DELETE("/:id/cards/:card_id", r.RemoveCard)
+	group.POST("/:id/shares", r.Share)
+	group.GET("/:id/shares", r.GetShares)
+	group.DELETE("/:id/shares/:user_id", r.RevokeShare)
+}
+
+func (r *Route) Create(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		resp   *presenter.DeckResponseWrapper
+		req    = payload.CreateDeckRequest{}
+		userId = c.Get("user_id").(int64)
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.UserId = userId
+	resp, err := r.UseCase.Deck.Create(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetList(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		req    = payload.GetListDeckRequest{}
+		resp   *presenter.ListDeckResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	if err := c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.UserId = userId
+	resp, err := r.UseCase.Deck.GetList(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetByID(c echo.Context) error {
+	var (
+		ctx   = &teq.CustomEchoContext{Context: c}
+		idStr = c.Param("id")
+		resp  *presenter.DeckResponseWrapper
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.Deck.GetByID(ctx, &payload.GetByIDRequest{ID: id})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) Update(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.DeckResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.UpdateDeckRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.ID = id
+	req.UserId = userId
+	resp, err = r.UseCase.Deck.Update(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) Delete(c echo.Context) error {
+	var (
+		ctx   = &teq.CustomEchoContext{Context: c}
+		idStr = c.Param("id")
+	)
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	err = r.UseCase.Deck.Delete(ctx, &payload.DeleteRequest{ID: id})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, nil)
+}
+
+func (r *Route) GetCards(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		req    = payload.GetListDeckCardRequest{}
+		resp   *presenter.ListDeckCardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	deckId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.DeckId = deckId
+	req.UserId = userId
+	resp, err = r.UseCase.Deck.GetCards(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) AddCard(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		req    = payload.AddDeckCardRequest{}
+		resp   *presenter.DeckCardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	deckId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.DeckId = deckId
+	req.UserId = userId
+	resp, err = r.UseCase.Deck.AddCard(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) MoveCard(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		req    = payload.MoveDeckCardRequest{}
+		resp   *presenter.DeckCardResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	deckId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	cardId, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.DeckId = deckId
+	req.CardId = cardId
+	req.UserId = userId
+	resp, err = r.UseCase.Deck.MoveCard(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) RemoveCard(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		userId = c.Get("user_id").(int64)
+	)
+
+	deckId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	cardId, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	err = r.UseCase.Deck.RemoveCard(ctx, &payload.RemoveDeckCardRequest{
+		DeckId: deckId,
+		CardId: cardId,
+		UserId: userId,
+	})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, nil)
+}
+
+func (r *Route) Share(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.DeckShareResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	deckId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req := payload.ShareDeckRequest{}
+	if err = c.Bind(&req); err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	req.DeckId = deckId
+	req.UserId = userId
+	resp, err = r.UseCase.Deck.Share(ctx, &req)
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) GetShares(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		resp   *presenter.ListDeckShareResponseWrapper
+		userId = c.Get("user_id").(int64)
+	)
+
+	deckId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	resp, err = r.UseCase.Deck.GetShares(ctx, &payload.GetListDeckShareRequest{DeckId: deckId, UserId: userId})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, resp)
+}
+
+func (r *Route) RevokeShare(c echo.Context) error {
+	var (
+		ctx    = &teq.CustomEchoContext{Context: c}
+		idStr  = c.Param("id")
+		userId = c.Get("user_id").(int64)
+	)
+
+	deckId, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	targetUserId, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
+	}
+
+	err = r.UseCase.Deck.RevokeShare(ctx, &payload.RevokeDeckShareRequest{
+		DeckId:       deckId,
+		TargetUserId: targetUserId,
+		UserId:       userId,
+	})
+	if err != nil {
+		return teq.Response.Error(c, err.(appError.TeqError))
+	}
+
+	return teq.Response.Success(c, nil)
+}
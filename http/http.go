@@ -1,29 +1,127 @@
 package http
 
 import (
+	"fmt"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"myapp/apiVersion"
 	"myapp/auth"
+	"myapp/config"
 	"myapp/http/appSession"
+	"myapp/openapi"
 	"myapp/repository"
 	"net/http"
+	"reflect"
 	"regexp"
+	"time"
 
 	"myapp/http/card"
+	"myapp/http/cardListing"
+	"myapp/http/cardStat"
+	"myapp/http/cardTransfer"
+	"myapp/http/cardType"
+	"myapp/http/deck"
+	"myapp/http/tag"
 	"myapp/http/user"
 	"myapp/usecase"
+	"myapp/validation"
 )
 
 type Route struct {
 	UseCase *usecase.UseCase
 }
 
+// info heads the API documentation.
+var info = openapi.Info{
+	Title: "Go-init API",
+	Description: "The routes under `/api` are v1, also served under `/api/v1`. `/api/v2` serves the v2 routes " +
+		"documented below and v1 for the others, `Accept: application/json; version=2` selects v2 on any path.",
+	Version: "1.0.0",
+}
+
+// versions of the API, oldest first, the deprecated ones are dated by the config.
+func versions() []apiVersion.Version {
+	cfg := config.GetConfig().API
+
+	versions := []apiVersion.Version{{Name: "v1"}, {Name: "v2"}}
+	for i := range versions {
+		versions[i].Deprecation = date(cfg.Deprecations[versions[i].Name])
+		versions[i].Sunset = date(cfg.Sunsets[versions[i].Name])
+	}
+
+	return versions
+}
+
+// date reads the `2006-01-02` dates of the config, a wrong one is reported and left out.
+func date(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		fmt.Println("API VERSION: ", err)
+	}
+
+	return t
+}
+
+// operations describe every route of the handler, OpenAPI reports the routes missing here.
+func operations() []openapi.Operation {
+	var ops []openapi.Operation
+	for _, group := range [][]openapi.Operation{
+		appSession.Operations,
+		user.Operations,
+		card.Operations,
+		cardStat.Operations,
+		cardTransfer.Operations,
+		cardListing.Operations,
+		tag.Operations,
+		deck.Operations,
+		cardType.Operations,
+	} {
+		ops = append(ops, group...)
+	}
+
+	return ops
+}
+
 func NewHTTPHandler(useCase *usecase.UseCase, repo *repository.Repository) *echo.Echo {
+	e, err := newHTTPHandler(useCase, repo)
+	if err != nil {
+		fmt.Println("OPENAPI: ", err)
+	}
+
+	return e
+}
+
+// OpenAPI returns the documentation of the API, the error tells which routes and operations drifted apart.
+func OpenAPI(useCase *usecase.UseCase, repo *repository.Repository) (*openapi.Document, error) {
+	e, _ := newHTTPHandler(useCase, repo)
+
+	return openapi.Build(e, info, operations()...)
+}
+
+func newHTTPHandler(useCase *usecase.UseCase, repo *repository.Repository) (*echo.Echo, error) {
 	var (
 		e         = echo.New()
 		loggerCfg = middleware.DefaultLoggerConfig
 	)
 
+	e.Binder = &validation.Binder{}
+
+	// a payload naming an unknown rule stops the start instead of its first request
+	for _, op := range operations() {
+		if op.Request == nil {
+			continue
+		}
+
+		if err := validation.Check(reflect.TypeOf(op.Request)); err != nil {
+			panic(err)
+		}
+	}
+
 	loggerCfg.Skipper = func(c echo.Context) bool {
 		return c.Request().URL.Path == "/health-check"
 	}
@@ -43,17 +141,47 @@ func NewHTTPHandler(useCase *usecase.UseCase, repo *repository.Repository) *echo
 			http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch,
 			http.MethodPost, http.MethodDelete, http.MethodOptions,
 		},
+		// readable by the browsers, the ETag of the versioned rows and the versions on their way out
+		ExposeHeaders: []string{"ETag", "Deprecation", "Sunset", "Link"},
 	}))
 
+	versioned := apiVersion.New(e, "/api", versions()...)
+	e.Pre(versioned.Resolve())
+
 	// APIs
 	api := e.Group("/api")
 	middlewares := auth.NewMiddlewareManager(repo.User)
 	userApi := api.Group("/users", middlewares.RequiredAuth)
 	cardApi := api.Group("/cards", middlewares.RequiredAuth)
+	transferApi := api.Group("/transfers", middlewares.RequiredAuth)
+	listingApi := api.Group("/listings", middlewares.RequiredAuth)
+	tagApi := api.Group("/tags", middlewares.RequiredAuth)
+	deckApi := api.Group("/decks", middlewares.RequiredAuth)
+	adminApi := api.Group("/admin", middlewares.RequiredAuth, middlewares.RequiredAdmin)
 
 	// Init groups APIs
 	appSession.Init(api.Group("/session"), useCase)
 	user.Init(userApi.Group(""), useCase)
 	card.Init(cardApi.Group(""), useCase)
-	return e
+	cardStat.Init(cardApi.Group("/stats"), useCase)
+	card.InitAttachment(api.Group("/attachments"), useCase)
+	card.InitTemplate(api.Group("/card_templates", middlewares.RequiredAuth), useCase)
+	cardTransfer.Init(transferApi.Group(""), useCase)
+	cardListing.Init(listingApi.Group(""), useCase)
+	tag.Init(tagApi.Group(""), useCase)
+	deck.Init(deckApi.Group(""), useCase)
+	cardType.Init(api.Group("/card_types"), useCase)
+	cardType.InitAdmin(adminApi.Group("/card_types"), useCase)
+	cardStat.InitAdmin(adminApi.Group("/stats"), useCase)
+
+	// v2 only registers the routes it changes
+	v2 := versioned.Group("v2")
+	card.InitV2(v2.Group("/cards", middlewares.RequiredAuth), useCase)
+
+	// documentation of the routes above
+	docs := openapi.Mount(api)
+	doc, err := openapi.Build(e, info, operations()...)
+	docs.SetDocument(doc)
+
+	return e, err
 }
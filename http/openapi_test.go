@@ -0,0 +1,51 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"myapp/mysql"
+	"myapp/repository"
+	"myapp/usecase"
+)
+
+// specPath is the committed document, `go test ./http -run TestOpenAPI -update` writes it again.
+const specPath = "../openapi.json"
+
+var update = flag.Bool("update", false, "write the OpenAPI document instead of comparing it")
+
+func TestOpenAPI(t *testing.T) {
+	repo := repository.New(mysql.GetClient)
+
+	doc, err := OpenAPI(usecase.New(repo), repo)
+	if err != nil {
+		t.Fatalf("routes and their documentation drifted apart: %v", err)
+	}
+
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec = append(spec, '\n')
+
+	if *update {
+		if err = os.WriteFile(specPath, spec, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	committed, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(spec, committed) {
+		t.Errorf("%s is out of date, run `make openapi` and commit the changes", specPath)
+	}
+}
@@ -0,0 +1,18 @@
+package tag
+
+import (
+	"myapp/openapi"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+// Operations describe the tag routes for the API documentation.
+var Operations = []openapi.Operation{
+	{
+		Handler:  (*Route).GetList,
+		Tag:      "Tags",
+		Summary:  "List the tags of the signed in user",
+		Request:  payload.GetListTagRequest{},
+		Response: presenter.ListTagResponseWrapper{},
+	},
+}
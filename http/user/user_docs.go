@@ -0,0 +1,42 @@
+package user
+
+import (
+	"myapp/customError"
+	"myapp/openapi"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+const tag = "Users"
+
+// Operations describe the user routes for the API documentation.
+var Operations = []openapi.Operation{
+	{
+		Handler:   (*Route).GetByID,
+		Tag:       tag,
+		Summary:   "Get a user",
+		Response:  presenter.UserResponseWrapper{},
+		Versioned: true,
+		Errors:    openapi.Errors(customError.ErrModelNotFound(), customError.ErrNoPermission()),
+	},
+	{
+		Handler:   (*Route).GetMyself,
+		Tag:       tag,
+		Summary:   "Get the signed in user",
+		Response:  presenter.UserResponseWrapper{},
+		Versioned: true,
+	},
+	{
+		Handler:   (*Route).Update,
+		Tag:       tag,
+		Summary:   "Update the signed in user",
+		Request:   payload.UpdateUserRequest{},
+		Response:  presenter.UserResponseWrapper{},
+		Versioned: true,
+	},
+	{
+		Handler: (*Route).Delete,
+		Tag:     tag,
+		Summary: "Delete the signed in user",
+	},
+}
@@ -48,6 +48,9 @@ func (r *Route) GetList(c echo.Context) error {
 		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
 	}
 
+	req.Filters = payload.FilterParams(c.QueryParams())
+	req.IncludeSet = c.QueryParams().Has("include")
+
 	resp, err := r.UseCase.User.GetList(ctx, &req)
 	if err != nil {
 		return teq.Response.Error(c, err.(appError.TeqError))
@@ -63,19 +66,27 @@ func (r *Route) Update(c echo.Context) error {
 		resp   *presenter.UserResponseWrapper
 	)
 
+	version, err := teq.IfMatch(c)
+	if err != nil {
+		return teq.Response.Error(ctx, err.(appError.TeqError))
+	}
+
 	req := payload.UpdateUserRequest{
-		ID: userId,
+		ID:      userId,
+		Version: version,
 	}
 
-	if err := c.Bind(&req); err != nil {
+	if err = c.Bind(&req); err != nil {
 		return teq.Response.Error(ctx, customError.ErrInvalidParams(err))
 	}
 
-	resp, err := r.UseCase.User.Update(ctx, &req)
+	resp, err = r.UseCase.User.Update(ctx, &req)
 	if err != nil {
 		return teq.Response.Error(c, err.(appError.TeqError))
 	}
 
+	c.Response().Header().Set("ETag", teq.ETag(resp.User.Version))
+
 	return teq.Response.Success(c, resp)
 }
 
@@ -96,7 +107,7 @@ func (r *Route) GetByID(c echo.Context) error {
 		return teq.Response.Error(c, err.(appError.TeqError))
 	}
 
-	return teq.Response.Success(c, resp)
+	return teq.Response.Versioned(c, resp.User.Version, resp)
 }
 
 func (r *Route) GetMyself(c echo.Context) error {
@@ -109,5 +120,5 @@ func (r *Route) GetMyself(c echo.Context) error {
 		return teq.Response.Error(c, err.(appError.TeqError))
 	}
 
-	return teq.Response.Success(c, resp)
+	return teq.Response.Versioned(c, resp.User.Version, resp)
 }
@@ -0,0 +1,83 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"myapp/config"
+	"myapp/usecase"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// List returns every background job of the service, each one can also be run once with `-task=<name>`.
+func List(useCase *usecase.UseCase) []Job {
+	cfg := config.GetConfig()
+
+	return []Job{
+		{
+			Name:     "expire_card_transfers",
+			Interval: cfg.Card.TransferExpireInterval,
+			Run:      useCase.CardTransfer.ExpirePending,
+		},
+		{
+			Name:     "purge_card_trash",
+			Interval: cfg.Card.TrashPurgeInterval,
+			Run:      useCase.Card.PurgeTrash,
+		},
+		{
+			Name:     "archive_expired_cards",
+			Interval: cfg.Card.ArchiveInterval,
+			Run:      useCase.Card.ArchiveExpired,
+		},
+		{
+			Name:     "aggregate_card_stats",
+			Interval: cfg.Stats.AggregateInterval,
+			Run:      useCase.CardStat.Aggregate,
+		},
+	}
+}
+
+// Start runs every job on its own ticker until ctx is done.
+func Start(ctx context.Context, jobs []Job) {
+	for i := range jobs {
+		go schedule(ctx, jobs[i])
+	}
+}
+
+// RunOnce runs the job named name a single time, it returns false if there is no such job.
+func RunOnce(ctx context.Context, jobs []Job, name string) (bool, error) {
+	for i := range jobs {
+		if jobs[i].Name == name {
+			return true, jobs[i].Run(ctx)
+		}
+	}
+
+	return false, nil
+}
+
+func schedule(ctx context.Context, j Job) {
+	if j.Interval <= 0 {
+		fmt.Println("JOB DISABLED: ", j.Name)
+		return
+	}
+
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.Run(ctx); err != nil {
+				fmt.Println("JOB "+j.Name+" ERROR: ", err)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"net"
+	"os"
 	"time"
 
 	"github.com/soheilhy/cmux"
@@ -12,6 +13,7 @@ import (
 
 	"myapp/config"
 	serviceHttp "myapp/http"
+	"myapp/job"
 	"myapp/migration"
 	"myapp/mysql"
 	"myapp/repository"
@@ -23,7 +25,7 @@ const VERSION = "1.0.0"
 func main() {
 	var (
 		//cfg     = config.GetConfig()
-		taskPtr = flag.String("task", "server", "server")
+		taskPtr = flag.String("task", "server", "server, openapi or the name of a background job to run once")
 	)
 
 	flag.Parse()
@@ -45,13 +47,41 @@ func main() {
 	switch *taskPtr {
 	case "server":
 		executeServer(useCase, repo, client)
+	case "openapi":
+		executeOpenAPI(useCase, repo)
 	default:
-		executeServer(useCase, repo, client)
+		if !executeJob(useCase, *taskPtr) {
+			executeServer(useCase, repo, client)
+		}
+	}
+}
+
+// executeOpenAPI fails when routes and their documentation drifted apart.
+func executeOpenAPI(useCase *usecase.UseCase, repo *repository.Repository) {
+	doc, err := serviceHttp.OpenAPI(useCase, repo)
+	if err != nil {
+		fmt.Println("OPENAPI: ", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("OPENAPI: %d paths documented\n", len(doc.Paths))
+}
+
+func executeJob(useCase *usecase.UseCase, name string) bool {
+	found, err := job.RunOnce(context.Background(), job.List(useCase), name)
+	if err != nil {
+		fmt.Println("JOB ERROR: ", err)
 	}
+
+	return found
 }
 
 func executeServer(useCase *usecase.UseCase, repo *repository.Repository, client func(ctx context.Context) *gorm.DB) {
 	cfg := config.GetConfig()
+	if err := cfg.Validate(); err != nil {
+		fmt.Println("ERROR CONFIG: ", err)
+		os.Exit(1)
+	}
 
 	// migration
 	migration.Up(client(context.Background()))
@@ -65,6 +95,9 @@ func executeServer(useCase *usecase.UseCase, repo *repository.Repository, client
 	httpL := m.Match(cmux.HTTP1Fast())
 	errs := make(chan error)
 
+	// background jobs
+	job.Start(context.Background(), job.List(useCase))
+
 	// http
 	{
 		h := serviceHttp.NewHTTPHandler(useCase, repo)
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS card_types
+(
+    `id`          BIGINT(20)   NOT NULL AUTO_INCREMENT,
+    `code`        VARCHAR(255) NOT NULL,
+    `name`        VARCHAR(255) NOT NULL,
+    `description` TEXT,
+    `icon`        VARCHAR(255),
+    `is_active`   BOOLEAN      NOT NULL DEFAULT TRUE,
+    `created_at`  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `deleted_at`  TIMESTAMP    NULL     DEFAULT NULL,
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_card_types_code` (`code`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+UPDATE cards
+SET `card_type` = TRIM(`card_type`)
+WHERE `card_type` IS NOT NULL;
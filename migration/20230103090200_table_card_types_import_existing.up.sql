@@ -0,0 +1,5 @@
+INSERT IGNORE INTO card_types (`code`, `name`, `is_active`)
+SELECT DISTINCT TRIM(`card_type`), TRIM(`card_type`), TRUE
+FROM cards
+WHERE `card_type` IS NOT NULL
+  AND TRIM(`card_type`) <> '';
@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD `is_admin` BOOLEAN NOT NULL DEFAULT FALSE
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS card_transfers
+(
+    `id`           BIGINT(20)  NOT NULL AUTO_INCREMENT,
+    `card_id`      BIGINT(20)  NOT NULL,
+    `from_user_id` BIGINT(20)  NOT NULL,
+    `to_user_id`   BIGINT(20)  NOT NULL,
+    `status`       VARCHAR(20) NOT NULL DEFAULT 'pending',
+    `expires_at`   TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `responded_at` TIMESTAMP   NULL     DEFAULT NULL,
+    `created_at`   TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`id`),
+    KEY `idx_card_transfers_card_status` (`card_id`, `status`),
+    KEY `idx_card_transfers_status_expires_at` (`status`, `expires_at`),
+    FOREIGN KEY (card_id) REFERENCES cards(id),
+    FOREIGN KEY (from_user_id) REFERENCES users(id),
+    FOREIGN KEY (to_user_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
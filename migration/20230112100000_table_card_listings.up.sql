@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS card_listings
+(
+    `id`           BIGINT(20)  NOT NULL AUTO_INCREMENT,
+    `card_id`      BIGINT(20)  NOT NULL,
+    `seller_id`    BIGINT(20)  NOT NULL,
+    `buyer_id`     BIGINT(20)  NULL     DEFAULT NULL,
+    `price`        INT         NOT NULL,
+    `fee`          INT         NOT NULL DEFAULT 0,
+    `status`       VARCHAR(20) NOT NULL DEFAULT 'active',
+    `sold_at`      TIMESTAMP   NULL     DEFAULT NULL,
+    `cancelled_at` TIMESTAMP   NULL     DEFAULT NULL,
+    `created_at`   TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`id`),
+    KEY `idx_card_listings_card_status` (`card_id`, `status`),
+    KEY `idx_card_listings_status` (`status`),
+    FOREIGN KEY (card_id) REFERENCES cards(id),
+    FOREIGN KEY (seller_id) REFERENCES users(id),
+    FOREIGN KEY (buyer_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS card_shares
+(
+    `id`         BIGINT(20)  NOT NULL AUTO_INCREMENT,
+    `card_id`    BIGINT(20)  NOT NULL,
+    `user_id`    BIGINT(20)  NOT NULL,
+    `permission` VARCHAR(20) NOT NULL DEFAULT 'viewer',
+    `created_at` TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_card_shares_card_user` (`card_id`, `user_id`),
+    KEY `idx_card_shares_user` (`user_id`),
+    FOREIGN KEY (card_id) REFERENCES cards(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS tags
+(
+    `id`         BIGINT(20)  NOT NULL AUTO_INCREMENT,
+    `user_id`    BIGINT(20)  NOT NULL,
+    `name`       VARCHAR(50) NOT NULL,
+    `created_at` TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_tags_user_name` (`user_id`, `name`),
+    KEY `idx_tags_name` (`name`),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS card_tags
+(
+    `card_id`    BIGINT(20) NOT NULL,
+    `tag_id`     BIGINT(20) NOT NULL,
+    `created_at` TIMESTAMP  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`card_id`, `tag_id`),
+    KEY `idx_card_tags_tag` (`tag_id`),
+    FOREIGN KEY (card_id) REFERENCES cards(id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
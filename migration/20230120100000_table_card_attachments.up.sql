@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS card_attachments
+(
+    `id`           BIGINT(20)   NOT NULL AUTO_INCREMENT,
+    `card_id`      BIGINT(20)   NOT NULL,
+    `user_id`      BIGINT(20)   NOT NULL,
+    `file_name`    VARCHAR(255) NOT NULL,
+    `content_type` VARCHAR(100) NOT NULL,
+    `size`         BIGINT(20)   NOT NULL DEFAULT 0,
+    `storage_key`  VARCHAR(255) NOT NULL,
+    `created_at`   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`id`),
+    KEY `idx_card_attachments_card` (`card_id`),
+    KEY `idx_card_attachments_user` (`user_id`),
+    FOREIGN KEY (card_id) REFERENCES cards(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
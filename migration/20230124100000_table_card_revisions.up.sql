@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS card_revisions
+(
+    `id`         BIGINT(20)  NOT NULL AUTO_INCREMENT,
+    `card_id`    BIGINT(20)  NOT NULL,
+    `revision`   INT         NOT NULL,
+    `action`     VARCHAR(20) NOT NULL,
+    `actor_id`   BIGINT(20)  NOT NULL,
+    `snapshot`   JSON        NOT NULL,
+    `created_at` TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_card_revisions_card_revision` (`card_id`, `revision`),
+    FOREIGN KEY (card_id) REFERENCES cards(id),
+    FOREIGN KEY (actor_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
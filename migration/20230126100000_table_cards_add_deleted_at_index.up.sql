@@ -0,0 +1 @@
+CREATE INDEX idx_cards_deleted_at ON cards (deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE cards ADD COLUMN `attributes` JSON NULL DEFAULT NULL AFTER `card_type`;
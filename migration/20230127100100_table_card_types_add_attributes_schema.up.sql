@@ -0,0 +1 @@
+ALTER TABLE card_types ADD COLUMN `attributes_schema` JSON NULL DEFAULT NULL AFTER `icon`;
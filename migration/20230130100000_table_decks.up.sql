@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS decks
+(
+    `id`          BIGINT(20)   NOT NULL AUTO_INCREMENT,
+    `user_id`     BIGINT(20)   NOT NULL,
+    `name`        VARCHAR(255) NOT NULL,
+    `description` TEXT,
+    `created_at`  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `deleted_at`  TIMESTAMP    NULL     DEFAULT NULL,
+
+    PRIMARY KEY (`id`),
+    KEY `idx_decks_user` (`user_id`),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS deck_cards
+(
+    `deck_id`    BIGINT(20)   NOT NULL,
+    `card_id`    BIGINT(20)   NOT NULL,
+    `position`   VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NOT NULL,
+    `created_at` TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`deck_id`, `card_id`),
+    KEY `idx_deck_cards_position` (`deck_id`, `position`),
+    KEY `idx_deck_cards_card` (`card_id`),
+    FOREIGN KEY (deck_id) REFERENCES decks(id),
+    FOREIGN KEY (card_id) REFERENCES cards(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
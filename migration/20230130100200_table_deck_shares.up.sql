@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS deck_shares
+(
+    `id`         BIGINT(20)  NOT NULL AUTO_INCREMENT,
+    `deck_id`    BIGINT(20)  NOT NULL,
+    `user_id`    BIGINT(20)  NOT NULL,
+    `permission` VARCHAR(20) NOT NULL DEFAULT 'viewer',
+    `created_at` TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_deck_shares_deck_user` (`deck_id`, `user_id`),
+    KEY `idx_deck_shares_user` (`user_id`),
+    FOREIGN KEY (deck_id) REFERENCES decks(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,5 @@
+ALTER TABLE cards
+    ADD COLUMN `active_from` TIMESTAMP NULL DEFAULT NULL AFTER `user_id`,
+    ADD COLUMN `expires_at`  TIMESTAMP NULL DEFAULT NULL AFTER `active_from`,
+    ADD COLUMN `archived_at` TIMESTAMP NULL DEFAULT NULL AFTER `expires_at`,
+    ADD KEY `idx_cards_expires_at` (`expires_at`);
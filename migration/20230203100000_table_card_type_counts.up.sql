@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS card_type_counts
+(
+    `user_id`    BIGINT(20)   NOT NULL,
+    `card_type`  VARCHAR(255) NOT NULL,
+    `total`      BIGINT(20)   NOT NULL DEFAULT 0,
+    `updated_at` TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`user_id`, `card_type`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS card_daily_creations
+(
+    `user_id`    BIGINT(20)   NOT NULL,
+    `day`        DATE         NOT NULL,
+    `card_type`  VARCHAR(255) NOT NULL,
+    `created`    BIGINT(20)   NOT NULL DEFAULT 0,
+    `updated_at` TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`user_id`, `day`, `card_type`),
+    KEY `idx_card_daily_creations_day` (`day`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
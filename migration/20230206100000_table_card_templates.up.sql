@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS card_templates
+(
+    `id`         BIGINT(20)   NOT NULL AUTO_INCREMENT,
+    `user_id`    BIGINT(20)   NOT NULL,
+    `name`       VARCHAR(255) NOT NULL,
+    `name_card`  VARCHAR(255) NOT NULL DEFAULT '',
+    `card_type`  VARCHAR(255) NOT NULL DEFAULT '',
+    `attributes` JSON         NULL,
+    `is_global`  TINYINT(1)   NOT NULL DEFAULT 0,
+    `created_at` TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `deleted_at` TIMESTAMP    NULL     DEFAULT NULL,
+
+    PRIMARY KEY (`id`),
+    KEY `idx_card_templates_user` (`user_id`),
+    KEY `idx_card_templates_global` (`is_global`),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
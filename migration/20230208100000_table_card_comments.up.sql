@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS card_comments
+(
+    `id`         BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `card_id`    BIGINT(20) NOT NULL,
+    `user_id`    BIGINT(20) NOT NULL,
+    `parent_id`  BIGINT(20) NULL     DEFAULT NULL,
+    `body`       TEXT       NOT NULL,
+    `edit_count` INT        NOT NULL DEFAULT 0,
+    `edited_at`  TIMESTAMP  NULL     DEFAULT NULL,
+    `created_at` TIMESTAMP  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP  NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `deleted_at` TIMESTAMP  NULL     DEFAULT NULL,
+
+    PRIMARY KEY (`id`),
+    KEY `idx_card_comments_thread` (`card_id`, `parent_id`, `id`),
+    KEY `idx_card_comments_parent` (`parent_id`),
+    FOREIGN KEY (card_id) REFERENCES cards(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
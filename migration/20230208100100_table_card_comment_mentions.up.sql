@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS card_comment_mentions
+(
+    `comment_id` BIGINT(20) NOT NULL,
+    `user_id`    BIGINT(20) NOT NULL,
+    `card_id`    BIGINT(20) NOT NULL,
+    `created_at` TIMESTAMP  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`comment_id`, `user_id`),
+    KEY `idx_card_comment_mentions_user` (`user_id`),
+    KEY `idx_card_comment_mentions_card` (`card_id`),
+    FOREIGN KEY (comment_id) REFERENCES card_comments(id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (card_id) REFERENCES cards(id)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+ALTER TABLE cards
+    ADD COLUMN `comment_count` INT NOT NULL DEFAULT 0 AFTER `attributes`;
@@ -0,0 +1,2 @@
+ALTER TABLE cards
+    ADD COLUMN `version` BIGINT NOT NULL DEFAULT 1 AFTER `comment_count`;
@@ -0,0 +1,2 @@
+ALTER TABLE card_listings
+    MODIFY `card_id` BIGINT(20) NULL DEFAULT NULL;
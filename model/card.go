@@ -6,12 +6,33 @@ import (
 )
 
 type Card struct {
-	ID        int64           `json:"id"`
-	NameCard  string          `json:"name_card"`
-	CardType  string          `json:"card_type"`
-	UserId    int64           `json:"user_id"`
-	User      User            `json:"user"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
-	DeletedAt *gorm.DeletedAt `json:"-"`
+	ID         int64  `json:"id"`
+	NameCard   string `json:"name_card"`
+	CardType   string `json:"card_type"`
+	Attributes JSON   `json:"attributes"`
+	// CommentCount is kept up to date by the comments, saving a card never writes it.
+	CommentCount int64           `json:"comment_count" gorm:"->"`
+	UserId       int64           `json:"user_id"`
+	User         User            `json:"user"`
+	Tags         []Tag           `json:"tags" gorm:"many2many:card_tags"`
+	ActiveFrom   *time.Time      `json:"active_from"`
+	ExpiresAt    *time.Time      `json:"expires_at"`
+	ArchivedAt   *time.Time      `json:"archived_at"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	DeletedAt    *gorm.DeletedAt `json:"-"`
+	Versioned
+}
+
+// IsActive reports whether the card is visible at now, cards are hidden before ActiveFrom and once expired.
+func (c *Card) IsActive(now time.Time) bool {
+	if c.ArchivedAt != nil {
+		return false
+	}
+
+	if c.ActiveFrom != nil && c.ActiveFrom.After(now) {
+		return false
+	}
+
+	return c.ExpiresAt == nil || c.ExpiresAt.After(now)
 }
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+type CardAttachment struct {
+	ID          int64     `json:"id"`
+	CardId      int64     `json:"card_id"`
+	UserId      int64     `json:"user_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	DownloadURL string    `json:"download_url,omitempty" gorm:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
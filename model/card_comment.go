@@ -0,0 +1,31 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// CardComment is either a comment on a card or a reply to one, replies always point to the first comment of the thread.
+type CardComment struct {
+	ID        int64      `json:"id"`
+	CardId    int64      `json:"card_id"`
+	UserId    int64      `json:"user_id"`
+	User      *User      `json:"user,omitempty"`
+	ParentId  *int64     `json:"parent_id"`
+	Body      string     `json:"body"`
+	EditCount int        `json:"edit_count"`
+	EditedAt  *time.Time `json:"edited_at"`
+	// ReplyCount is only filled by the comment list.
+	ReplyCount int64           `json:"reply_count" gorm:"->"`
+	Mentions   []User          `json:"mentions" gorm:"many2many:card_comment_mentions;joinForeignKey:CommentId;joinReferences:UserId"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  *gorm.DeletedAt `json:"-"`
+}
+
+type CardCommentMention struct {
+	CommentId int64     `json:"comment_id" gorm:"primaryKey"`
+	UserId    int64     `json:"user_id" gorm:"primaryKey"`
+	CardId    int64     `json:"card_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	CardListingStatusActive    = "active"
+	CardListingStatusSold      = "sold"
+	CardListingStatusCancelled = "cancelled"
+)
+
+type CardListing struct {
+	ID int64 `json:"id"`
+	// CardId is 0 once the card is purged, the row stays as the record of the sale.
+	CardId      int64      `json:"card_id"`
+	Card        *Card      `json:"card,omitempty"`
+	SellerId    int64      `json:"seller_id"`
+	BuyerId     *int64     `json:"buyer_id"`
+	Price       int        `json:"price"`
+	Fee         int        `json:"fee"`
+	Status      string     `json:"status"`
+	SoldAt      *time.Time `json:"sold_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
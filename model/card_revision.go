@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	CardRevisionActionCreate   = "create"
+	CardRevisionActionUpdate   = "update"
+	CardRevisionActionDelete   = "delete"
+	CardRevisionActionRestore  = "restore"
+	CardRevisionActionUndelete = "undelete"
+	CardRevisionActionTransfer = "transfer"
+	CardRevisionActionSale     = "sale"
+)
+
+type CardRevision struct {
+	ID        int64     `json:"id"`
+	CardId    int64     `json:"card_id"`
+	Revision  int       `json:"revision"`
+	Action    string    `json:"action"`
+	ActorId   int64     `json:"actor_id"`
+	Snapshot  JSON      `json:"snapshot"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CardSnapshot holds the fields of a card that are versioned by its revisions.
+type CardSnapshot struct {
+	NameCard   string `json:"name_card"`
+	CardType   string `json:"card_type"`
+	Attributes JSON   `json:"attributes,omitempty"`
+	UserId     int64  `json:"user_id"`
+}
+
+func SnapshotOf(card *Card) CardSnapshot {
+	return CardSnapshot{
+		NameCard:   card.NameCard,
+		CardType:   card.CardType,
+		Attributes: card.Attributes,
+		UserId:     card.UserId,
+	}
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	CardPermissionViewer = "viewer"
+	CardPermissionEditor = "editor"
+	CardPermissionOwner  = "owner"
+)
+
+// cardPermissionLevels orders the permissions, a higher level includes every lower one.
+var cardPermissionLevels = map[string]int{
+	CardPermissionViewer: 1,
+	CardPermissionEditor: 2,
+	CardPermissionOwner:  3,
+}
+
+type CardShare struct {
+	ID         int64     `json:"id"`
+	CardId     int64     `json:"card_id"`
+	UserId     int64     `json:"user_id"`
+	User       *User     `json:"user,omitempty"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func IsValidSharePermission(permission string) bool {
+	return permission == CardPermissionViewer || permission == CardPermissionEditor
+}
+
+// HasCardPermission reports whether granted is at least as strong as required.
+func HasCardPermission(granted string, required string) bool {
+	grantedLevel, ok := cardPermissionLevels[granted]
+	if !ok {
+		return false
+	}
+
+	return grantedLevel >= cardPermissionLevels[required]
+}
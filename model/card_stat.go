@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// CardTypeCount is the rollup of the live cards of a user per card type.
+type CardTypeCount struct {
+	UserId    int64     `json:"user_id" gorm:"primaryKey"`
+	CardType  string    `json:"card_type" gorm:"primaryKey"`
+	Total     int64     `json:"total"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CardDailyCreation is the rollup of the cards created by a user per day, Day is a YYYY-MM-DD date
+// in the stats timezone.
+type CardDailyCreation struct {
+	UserId    int64     `json:"user_id" gorm:"primaryKey"`
+	Day       string    `json:"day" gorm:"primaryKey"`
+	CardType  string    `json:"card_type" gorm:"primaryKey"`
+	Created   int64     `json:"created"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// CardTemplate holds the defaults of new cards, global templates are curated by admins and seen by everyone.
+type CardTemplate struct {
+	ID         int64           `json:"id"`
+	UserId     int64           `json:"user_id"`
+	Name       string          `json:"name"`
+	NameCard   string          `json:"name_card"`
+	CardType   string          `json:"card_type"`
+	Attributes JSON            `json:"attributes"`
+	IsGlobal   bool            `json:"is_global"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  *gorm.DeletedAt `json:"-"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	CardTransferStatusPending   = "pending"
+	CardTransferStatusAccepted  = "accepted"
+	CardTransferStatusDeclined  = "declined"
+	CardTransferStatusCancelled = "cancelled"
+	CardTransferStatusExpired   = "expired"
+)
+
+type CardTransfer struct {
+	ID int64 `json:"id"`
+	// CardId is 0 once the card is purged, the row stays as the record of the transfer.
+	CardId      int64      `json:"card_id"`
+	Card        *Card      `json:"card,omitempty"`
+	FromUserId  int64      `json:"from_user_id"`
+	ToUserId    int64      `json:"to_user_id"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (t *CardTransfer) IsExpired(now time.Time) bool {
+	return t.Status == CardTransferStatusPending && !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+type CardType struct {
+	ID               int64           `json:"id"`
+	Code             string          `json:"code"`
+	Name             string          `json:"name"`
+	Description      string          `json:"description"`
+	Icon             string          `json:"icon"`
+	AttributesSchema JSON            `json:"attributes_schema"`
+	IsActive         bool            `json:"is_active"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	DeletedAt        *gorm.DeletedAt `json:"-"`
+}
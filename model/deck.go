@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Deck struct {
+	ID          int64           `json:"id"`
+	UserId      int64           `json:"user_id"`
+	User        *User           `json:"user,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	DeletedAt   *gorm.DeletedAt `json:"-"`
+}
+
+// DeckCard places a card in a deck, the cards of a deck are ordered by the fractional index Position.
+type DeckCard struct {
+	DeckId    int64     `json:"deck_id" gorm:"primaryKey"`
+	CardId    int64     `json:"card_id" gorm:"primaryKey"`
+	Card      *Card     `json:"card,omitempty"`
+	Position  string    `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DeckShare grants a user access to a deck, decks use the same permission levels as cards.
+type DeckShare struct {
+	ID         int64     `json:"id"`
+	DeckId     int64     `json:"deck_id"`
+	UserId     int64     `json:"user_id"`
+	User       *User     `json:"user,omitempty"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+type Tag struct {
+	ID         int64     `json:"id"`
+	UserId     int64     `json:"user_id"`
+	Name       string    `json:"name"`
+	UsageCount *int64    `json:"usage_count,omitempty" gorm:"->"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type CardTag struct {
+	CardId    int64     `json:"card_id" gorm:"primaryKey"`
+	TagId     int64     `json:"tag_id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+}
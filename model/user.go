@@ -12,8 +12,10 @@ type User struct {
 	Username  string          `json:"username"`
 	Password  string          `json:"-"`
 	Score     int             `json:"score"`
+	IsAdmin   bool            `json:"is_admin"`
 	Cards     []Card          `json:"cards"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 	DeletedAt *gorm.DeletedAt `json:"-"`
+	Versioned
 }
@@ -0,0 +1,12 @@
+package model
+
+// Versioned counts the updates of a row. An update only applies to the version it read and moves it by one,
+// the version is also the ETag of the row.
+type Versioned struct {
+	Version int64 `json:"version"`
+}
+
+// VersionRef lets the repositories move the version of the models embedding Versioned.
+func (v *Versioned) VersionRef() *int64 {
+	return &v.Version
+}
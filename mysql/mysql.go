@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 
+	"myapp/appError"
 	"myapp/config"
 	"myapp/customError"
 )
@@ -26,6 +28,7 @@ func TxBegin(ctx context.Context, getClient func(ctx context.Context) *gorm.DB)
 	tx := db.Begin()
 	ctx = SetTx(ctx, tx)
 
+	ctx = context.WithValue(ctx, keyGetClient, getClient)
 	ctx = context.WithValue(ctx, keyEnable, true)
 
 	return ctx
@@ -112,6 +115,12 @@ func GetGetClientFunc(ctx context.Context) func(ctx context.Context) *gorm.DB {
 }
 
 func IsDeadlockError(err error) bool {
+	// use cases wrap driver errors, the deadlock message is only kept in the raw error
+	var teqErr appError.TeqError
+	if errors.As(err, &teqErr) && teqErr.Raw != nil {
+		err = teqErr.Raw
+	}
+
 	return strings.Contains(strings.ToLower(err.Error()), "deadlock")
 }
 
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"myapp/validation"
+)
+
+// constrain adds the `validate` rules of field to its schema, the same rules the binder checks.
+// It reports whether the field is required, references are left as they are.
+func constrain(schema *Schema, field reflect.StructField) bool {
+	var required bool
+
+	for _, c := range validation.Constraints(field) {
+		switch {
+		case c.Rule == "required":
+			required = true
+		case schema.Ref != "":
+			continue
+		case c.Rule == "email":
+			schema.Format = "email"
+		case c.Rule == "oneof":
+			for _, value := range strings.Fields(c.Param) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		case c.Rule == "min" || c.Rule == "max":
+			limit, err := strconv.ParseFloat(c.Param, 64)
+			if err != nil {
+				continue
+			}
+
+			bound(schema, c.Rule == "min", limit)
+		case c.Rule == "notblank":
+			if schema.Type == "string" {
+				one := 1
+				schema.MinLength = &one
+			}
+		case c.Rule == "required_without":
+			schema.Description = strings.TrimSpace(schema.Description + " Required without `" + c.Param + "`.")
+		default:
+			// the custom rules only have a name to show
+			schema.Description = strings.TrimSpace(schema.Description + " Validated as `" + c.Rule + "`.")
+		}
+	}
+
+	return required
+}
+
+// bound sets the length of strings, the size of arrays or the value of numbers.
+func bound(schema *Schema, min bool, limit float64) {
+	size := int(limit)
+
+	switch schema.Type {
+	case "string":
+		if min {
+			schema.MinLength = &size
+		} else {
+			schema.MaxLength = &size
+		}
+	case "array":
+		if min {
+			schema.MinItems = &size
+		} else {
+			schema.MaxItems = &size
+		}
+	case "integer", "number":
+		if min {
+			schema.Minimum = &limit
+		} else {
+			schema.Maximum = &limit
+		}
+	}
+}
@@ -0,0 +1,416 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/validation"
+)
+
+// Operation describes the route served by Handler, which is the method expression of the handler,
+// `(*Route).GetByID` for a route registered with `r.GetByID`.
+type Operation struct {
+	Handler     interface{}
+	Tag         string
+	Summary     string
+	Description string
+	// Request is the payload the handler binds, from the query string on GET and DELETE, from the body otherwise.
+	Request interface{}
+	// Parameters are read by the handler itself, outside of Request.
+	Parameters []Parameter
+	// Files are the multipart file fields, the body is then sent as a form.
+	Files []string
+	// Response is the data of the teq.Response envelope, nil when the handler answers with no data.
+	Response interface{}
+	// Produces is the content type of a response written as is, outside of the envelope.
+	Produces string
+	// NoContent routes answer 204 with no body.
+	NoContent bool
+	// Versioned routes answer with the ETag of the row. Reads answer 304 when If-None-Match holds it,
+	// updates require If-Match and only apply to that version.
+	Versioned bool
+	// Public routes don't require a token.
+	Public bool
+	// Errors are the errors of the route besides the invalid parameters, the missing token and the server errors
+	// which every route documents.
+	Errors []appError.TeqError
+}
+
+// Parameter is a query or header parameter, Type is a JSON schema type.
+type Parameter struct {
+	In          string
+	Name        string
+	Description string
+	Type        string
+	Required    bool
+}
+
+// serverErrors can be returned by every route.
+var serverErrors = []appError.TeqError{
+	customError.ErrModelGet(nil, "record"),
+	customError.ErrModelCreate(nil),
+	customError.ErrModelUpdate(nil),
+	customError.ErrModelDelete(nil),
+	customError.ErrCommitTransaction(nil),
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// handlerName is the name echo gives the route of a handler, method values carry a `-fm` suffix.
+func handlerName(handler interface{}) string {
+	name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// Build documents the routes of e with operations. The document is complete even when routes and operations
+// drifted apart, the error then lists the routes without an operation and the operations without a route.
+func Build(e *echo.Echo, info Info, operations ...Operation) (*Document, error) {
+	var (
+		doc = &Document{
+			OpenAPI: "3.0.3",
+			Info:    info,
+			Paths:   make(map[string]map[string]*operationObject),
+			Components: components{
+				Schemas: make(schemas),
+				SecuritySchemes: map[string]securityScheme{
+					"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				},
+			},
+		}
+		byHandler = make(map[string]Operation, len(operations))
+		routed    = make(map[string]bool, len(operations))
+		drift     []string
+	)
+
+	for _, op := range append(operations, ownOperations...) {
+		byHandler[handlerName(op.Handler)] = op
+	}
+
+	routes := e.Routes()
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+
+		return routes[i].Method < routes[j].Method
+	})
+
+	notFound := handlerName(echo.NotFoundHandler)
+
+	for _, route := range routes {
+		name := strings.TrimSuffix(route.Name, "-fm")
+		if name == notFound {
+			// catch-all routes echo adds to the groups with middleware
+			continue
+		}
+
+		op, ok := byHandler[name]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("%s %s has no operation", route.Method, route.Path))
+			continue
+		}
+
+		routed[name] = true
+
+		p := pathParam.ReplaceAllString(route.Path, "{$1}")
+		if doc.Paths[p] == nil {
+			doc.Paths[p] = make(map[string]*operationObject)
+		}
+
+		doc.Paths[p][strings.ToLower(route.Method)] = describe(doc.Components.Schemas, route, op)
+	}
+
+	for name := range byHandler {
+		if !routed[name] {
+			drift = append(drift, fmt.Sprintf("%s has no route", name))
+		}
+	}
+
+	if len(drift) > 0 {
+		sort.Strings(drift)
+
+		return doc, fmt.Errorf("routes and operations drifted apart: %s", strings.Join(drift, "; "))
+	}
+
+	return doc, nil
+}
+
+func describe(s schemas, route *echo.Route, op Operation) *operationObject {
+	o := &operationObject{
+		Summary:     op.Summary,
+		Description: op.Description,
+		OperationID: operationID(route),
+		Responses:   make(map[string]*responseObject),
+	}
+
+	if op.Tag != "" {
+		o.Tags = []string{op.Tag}
+	}
+
+	errs := append([]appError.TeqError{}, op.Errors...)
+
+	// every path parameter is an id
+	for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		o.Parameters = append(o.Parameters, parameterObject{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "integer", Format: "int64"},
+		})
+	}
+
+	if len(o.Parameters) > 0 || op.Request != nil || len(op.Files) > 0 {
+		errs = append(errs, customError.ErrInvalidParams(nil))
+	}
+
+	if op.Request != nil && validation.Validates(reflect.TypeOf(op.Request)) {
+		errs = append(errs, customError.ErrInvalidFields(nil))
+	}
+
+	if op.Request != nil {
+		describeRequest(s, o, route.Method, reflect.TypeOf(op.Request), op.Files)
+	} else if len(op.Files) > 0 {
+		describeRequest(s, o, route.Method, nil, op.Files)
+	}
+
+	for _, param := range op.Parameters {
+		o.Parameters = append(o.Parameters, parameterObject{
+			Name:        param.Name,
+			In:          param.In,
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      &Schema{Type: param.Type},
+		})
+	}
+
+	if !op.Public {
+		o.Security = []map[string][]string{{"bearerAuth": {}}}
+		errs = append(errs, customError.ErrUnauthorized(nil))
+	}
+
+	if op.NoContent {
+		o.Responses["204"] = &responseObject{Description: "No Content"}
+	} else if op.Produces != "" {
+		o.Responses["200"] = &responseObject{
+			Description: "OK",
+			Content:     map[string]mediaTypeObject{op.Produces: {Schema: &Schema{Type: "string", Format: "binary"}}},
+		}
+	} else {
+		data := &Schema{Nullable: true}
+		if op.Response != nil {
+			data = s.of(reflect.TypeOf(op.Response))
+		}
+
+		o.Responses["200"] = &responseObject{
+			Description: "OK",
+			Content: map[string]mediaTypeObject{echo.MIMEApplicationJSON: {Schema: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"code":    {Type: "integer", Enum: []interface{}{http.StatusOK}},
+					"message": {Type: "string"},
+					"data":    data,
+				},
+			}}},
+		}
+	}
+
+	if op.Versioned {
+		errs = append(errs, describeVersion(o, route.Method)...)
+	}
+
+	describeErrors(o, append(errs, serverErrors...))
+
+	return o
+}
+
+// describeRequest reads the query parameters of GET and DELETE from the query tags of the payload,
+// other methods take the payload as json body or, with files, as multipart form.
+func describeRequest(s schemas, o *operationObject, method string, t reflect.Type, files []string) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if method == http.MethodGet || method == http.MethodDelete {
+		eachField(t, "query", func(name string, field reflect.StructField) {
+			schema := s.of(field.Type)
+			o.Parameters = append(o.Parameters, parameterObject{Name: name, In: "query", Schema: schema, Required: constrain(schema, field)})
+		})
+
+		return
+	}
+
+	if len(files) == 0 {
+		o.RequestBody = &requestBodyObject{
+			Required: true,
+			Content:  map[string]mediaTypeObject{echo.MIMEApplicationJSON: {Schema: s.of(t)}},
+		}
+
+		return
+	}
+
+	form := &Schema{Type: "object", Properties: make(map[string]*Schema), Required: files}
+	for _, file := range files {
+		form.Properties[file] = &Schema{Type: "string", Format: "binary"}
+	}
+
+	if t != nil {
+		eachField(t, "form", func(name string, field reflect.StructField) {
+			form.Properties[name] = s.of(field.Type)
+			if constrain(form.Properties[name], field) {
+				form.Required = append(form.Required, name)
+			}
+		})
+	}
+
+	o.RequestBody = &requestBodyObject{
+		Required: true,
+		Content:  map[string]mediaTypeObject{echo.MIMEMultipartForm: {Schema: form}},
+	}
+}
+
+// describeVersion adds the conditional headers of a versioned route, it returns the errors of its preconditions.
+func describeVersion(o *operationObject, method string) []appError.TeqError {
+	if o.Responses["200"] != nil {
+		o.Responses["200"].Headers = map[string]*headerObject{
+			"ETag": {Description: "Version of the row, `\"3\"`.", Schema: &Schema{Type: "string"}},
+		}
+	}
+
+	if method == http.MethodGet {
+		o.Parameters = append(o.Parameters, parameterObject{
+			Name:        "If-None-Match",
+			In:          "header",
+			Description: "ETag the client holds, the row is only sent when it changed.",
+			Schema:      &Schema{Type: "string"},
+		})
+		o.Responses["304"] = &responseObject{Description: "Not Modified"}
+
+		return nil
+	}
+
+	o.Parameters = append(o.Parameters, parameterObject{
+		Name:        "If-Match",
+		In:          "header",
+		Description: "ETag the update was made from, `*` updates any version.",
+		Required:    true,
+		Schema:      &Schema{Type: "string"},
+	})
+
+	return []appError.TeqError{
+		customError.ErrRequestInvalidParam("If-Match"),
+		customError.ErrPreconditionRequired(),
+		customError.ErrPreconditionFailed(),
+	}
+}
+
+// fieldErrors lists the fields refused by the validation, in the `errors` of the envelope.
+var fieldErrors = &Schema{Type: "array", Items: &Schema{
+	Type:     "object",
+	Required: []string{"field", "code", "message"},
+	Properties: map[string]*Schema{
+		"field":   {Type: "string", Description: "Name of the field, nested ones are joined with dots and indexes."},
+		"code":    {Type: "string", Description: "Rule the field broke, `required`, `max`, `email`..."},
+		"message": {Type: "string"},
+	},
+}}
+
+// describeErrors adds one response per status, listing the error codes of the teq.Response error envelope.
+func describeErrors(o *operationObject, errs []appError.TeqError) {
+	var (
+		byStatus = make(map[int][]appError.TeqError)
+		seen     = make(map[string]bool)
+	)
+
+	for _, err := range errs {
+		key := err.ErrorCode + err.Message
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		byStatus[err.HTTPCode] = append(byStatus[err.HTTPCode], err)
+	}
+
+	for status, errs := range byStatus {
+		var (
+			codes = make([]interface{}, 0, len(errs))
+			lines = make([]string, 0, len(errs))
+		)
+
+		sort.Slice(errs, func(i, j int) bool { return errs[i].ErrorCode < errs[j].ErrorCode })
+
+		for _, err := range errs {
+			if len(codes) == 0 || codes[len(codes)-1] != err.ErrorCode {
+				codes = append(codes, err.ErrorCode)
+			}
+
+			lines = append(lines, fmt.Sprintf("`%s` %s", err.ErrorCode, err.Message))
+		}
+
+		envelope := &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"code":    {Type: "string", Enum: codes},
+				"message": {Type: "string"},
+				"info":    {Type: "string"},
+			},
+		}
+
+		for _, err := range errs {
+			if err.ErrorCode == customError.ErrInvalidFields(nil).ErrorCode {
+				envelope.Properties["errors"] = fieldErrors
+			}
+		}
+
+		o.Responses[fmt.Sprint(status)] = &responseObject{
+			Description: strings.Join(lines, "\n\n"),
+			Content:     map[string]mediaTypeObject{echo.MIMEApplicationJSON: {Schema: envelope}},
+		}
+	}
+}
+
+// operationID is made of the method and the segments of the path, `get_api_cards_id_comments`.
+func operationID(route *echo.Route) string {
+	parts := []string{strings.ToLower(route.Method)}
+	for _, segment := range strings.Split(route.Path, "/") {
+		if segment != "" {
+			parts = append(parts, strings.TrimPrefix(segment, ":"))
+		}
+	}
+
+	return strings.Join(parts, "_")
+}
+
+// InvalidParams lists the invalid parameter errors of params.
+func InvalidParams(params ...string) []appError.TeqError {
+	errs := make([]appError.TeqError, 0, len(params))
+	for _, param := range params {
+		errs = append(errs, customError.ErrRequestInvalidParam(param))
+	}
+
+	return errs
+}
+
+// Errors joins error lists, `Errors(InvalidParams("name"), customError.ErrModelNotFound())`.
+func Errors(errs ...interface{}) []appError.TeqError {
+	joined := make([]appError.TeqError, 0, len(errs))
+	for _, err := range errs {
+		switch err := err.(type) {
+		case appError.TeqError:
+			joined = append(joined, err)
+		case []appError.TeqError:
+			joined = append(joined, err...)
+		}
+	}
+
+	return joined
+}
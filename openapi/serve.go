@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"myapp/customError"
+	"myapp/teq"
+)
+
+//go:embed swagger.html
+var swaggerPage []byte
+
+// Handler serves the document and the Swagger UI page reading it.
+type Handler struct {
+	doc *Document
+}
+
+// ownOperations describe the routes of Mount, Build always knows them.
+var ownOperations = []Operation{
+	{
+		Handler:  (*Handler).Spec,
+		Tag:      "Documentation",
+		Summary:  "OpenAPI document of the API",
+		Produces: echo.MIMEApplicationJSON,
+		Public:   true,
+	},
+	{
+		Handler:  (*Handler).Page,
+		Tag:      "Documentation",
+		Summary:  "Swagger UI page",
+		Produces: echo.MIMETextHTML,
+		Public:   true,
+	},
+}
+
+// Mount registers `/openapi.json` and `/docs` on group, SetDocument hands them the document once it is built.
+func Mount(group *echo.Group) *Handler {
+	h := &Handler{}
+	group.GET("/openapi.json", h.Spec)
+	group.GET("/docs", h.Page)
+
+	return h
+}
+
+func (h *Handler) SetDocument(doc *Document) {
+	h.doc = doc
+}
+
+func (h *Handler) Spec(c echo.Context) error {
+	if h.doc == nil {
+		return teq.Response.Error(c, customError.ErrModelNotFound())
+	}
+
+	return c.JSON(http.StatusOK, h.doc)
+}
+
+func (h *Handler) Page(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, swaggerPage)
+}
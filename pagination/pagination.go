@@ -0,0 +1,355 @@
+package pagination
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points at the row a page starts after, or ends before when Before is set.
+// It is handed out opaque, clients only pass it back.
+type Cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+	Before bool     `json:"b,omitempty"`
+}
+
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err = json.Unmarshal(data, &c); err != nil || c.Sort == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// Key is one column of the list order, Column is the database column on the table of the list.
+type Key struct {
+	Column string
+	Desc   bool
+}
+
+// Keyset orders a list on Keys, which always end with the id so rows keep their place while others are added
+// or removed. The key columns must not be nullable. A Limit of 0 or less returns every row.
+type Keyset struct {
+	Keys   []Key
+	Cursor *Cursor
+	Limit  int
+	// Offset is only used without a cursor, it keeps the page parameter working.
+	Offset int
+}
+
+// NewKeyset starts from the encoded cursor when there is one, a cursor of another order is refused.
+// The id is added as last key when keys don't hold it, keys after the id are dropped since they can't matter.
+func NewKeyset(keys []Key, cursor string, page int, limit int) (*Keyset, error) {
+	k := &Keyset{Limit: limit}
+
+	for i := range keys {
+		k.Keys = append(k.Keys, keys[i])
+		if keys[i].Column == "id" {
+			break
+		}
+	}
+
+	if len(k.Keys) == 0 || k.Keys[len(k.Keys)-1].Column != "id" {
+		// the tie breaker follows the direction of the last key
+		k.Keys = append(k.Keys, Key{Column: "id", Desc: len(k.Keys) > 0 && k.Keys[len(k.Keys)-1].Desc})
+	}
+
+	if cursor != "" {
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.Sort != k.signature() || len(c.Values) != len(k.Keys) {
+			return nil, ErrInvalidCursor
+		}
+
+		k.Cursor = c
+		return k, nil
+	}
+
+	if page > 1 && limit > 0 {
+		k.Offset = limit * (page - 1)
+	}
+
+	return k, nil
+}
+
+// signature identifies the order a cursor was made for.
+func (k *Keyset) signature() string {
+	parts := make([]string, 0, len(k.Keys))
+	for i := range k.Keys {
+		if k.Keys[i].Desc {
+			parts = append(parts, "-"+k.Keys[i].Column)
+		} else {
+			parts = append(parts, k.Keys[i].Column)
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Columns returns the columns the list is ordered on, a partial read must select them for the cursors.
+func (k *Keyset) Columns() []string {
+	columns := make([]string, 0, len(k.Keys))
+	for i := range k.Keys {
+		columns = append(columns, k.Keys[i].Column)
+	}
+
+	return columns
+}
+
+// Page holds the cursors around the rows returned, an empty cursor means there is nothing in that direction.
+type Page struct {
+	NextCursor string
+	PrevCursor string
+}
+
+// backward reports whether the rows are read in the opposite of the list order.
+func (k *Keyset) backward() bool {
+	return k.Cursor != nil && k.Cursor.Before
+}
+
+func (k *Keyset) fields(db *gorm.DB) ([]*schema.Field, error) {
+	if db.Statement.Schema == nil {
+		if err := db.Statement.Parse(db.Statement.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make([]*schema.Field, 0, len(k.Keys))
+	for i := range k.Keys {
+		field := db.Statement.Schema.LookUpField(k.Keys[i].Column)
+		if field == nil {
+			return nil, fmt.Errorf("pagination: unknown column %s", k.Keys[i].Column)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// Apply adds the order, the cursor condition and the limit to db, which must have its model set.
+// One more row than the limit is read to find out whether there is a next page.
+func (k *Keyset) Apply(db *gorm.DB) (*gorm.DB, error) {
+	fields, err := k.fields(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		table    = db.Statement.Table
+		backward = k.backward()
+		columns  = make([]clause.Column, 0, len(fields))
+		desc     = make([]bool, 0, len(fields))
+	)
+
+	for i := range fields {
+		columns = append(columns, clause.Column{Table: table, Name: fields[i].DBName})
+		desc = append(desc, k.Keys[i].Desc != backward)
+	}
+
+	if k.Cursor != nil {
+		values := make([]interface{}, 0, len(fields))
+		for i := range fields {
+			value, err := parseValue(fields[i], k.Cursor.Values[i])
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+		}
+
+		db = db.Where(after(columns, desc, values))
+	}
+
+	for i := range columns {
+		db = db.Order(clause.OrderByColumn{Column: columns[i], Desc: desc[i]})
+	}
+
+	if k.Limit > 0 {
+		db = db.Limit(k.Limit + 1)
+	}
+
+	if k.Cursor == nil && k.Offset > 0 {
+		db = db.Offset(k.Offset)
+	}
+
+	return db, nil
+}
+
+// after matches the rows which come after values in the order of columns,
+// (a, b) > (1, 2) turns into a > 1 OR (a = 1 AND b > 2).
+func after(columns []clause.Column, desc []bool, values []interface{}) clause.Expression {
+	alternatives := make([]clause.Expression, 0, len(columns))
+
+	for i := range columns {
+		op := " > "
+		if desc[i] {
+			op = " < "
+		}
+
+		terms := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, clause.Eq{Column: columns[j], Value: values[j]})
+		}
+
+		terms = append(terms, clause.Expr{SQL: "?" + op + "?", Vars: []interface{}{columns[i], values[i]}})
+		alternatives = append(alternatives, clause.And(terms...))
+	}
+
+	return clause.Or(alternatives...)
+}
+
+// Finish drops the extra row read by Apply, puts the rows back in list order and returns the cursors around them.
+// db is the statement the rows were read with.
+func Finish[T any](k *Keyset, db *gorm.DB, rows []T) ([]T, Page, error) {
+	var page Page
+
+	more := k.Limit > 0 && len(rows) > k.Limit
+	if more {
+		rows = rows[:k.Limit]
+	}
+
+	if k.backward() {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	if len(rows) == 0 {
+		return rows, page, nil
+	}
+
+	first, err := k.cursorOf(db, &rows[0], true)
+	if err != nil {
+		return nil, page, err
+	}
+
+	last, err := k.cursorOf(db, &rows[len(rows)-1], false)
+	if err != nil {
+		return nil, page, err
+	}
+
+	switch {
+	case k.backward():
+		if more {
+			page.PrevCursor = first
+		}
+
+		page.NextCursor = last
+	default:
+		if more {
+			page.NextCursor = last
+		}
+
+		if k.Cursor != nil || k.Offset > 0 {
+			page.PrevCursor = first
+		}
+	}
+
+	return rows, page, nil
+}
+
+func (k *Keyset) cursorOf(db *gorm.DB, row interface{}, before bool) (string, error) {
+	fields, err := k.fields(db)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		ctx = context.Background()
+		rv  = reflect.ValueOf(row)
+		c   = &Cursor{Sort: k.signature(), Values: make([]string, 0, len(fields)), Before: before}
+	)
+
+	for i := range fields {
+		value, _ := fields[i].ValueOf(ctx, rv)
+		c.Values = append(c.Values, formatValue(value))
+	}
+
+	return c.Encode(), nil
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseValue turns the cursor value back into the type of the column, so the driver compares it the same way.
+func parseValue(field *schema.Field, value string) (interface{}, error) {
+	switch field.FieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+
+		return v, nil
+	case reflect.String:
+		return value, nil
+	}
+
+	if field.FieldType == reflect.TypeOf(time.Time{}) || field.FieldType == reflect.TypeOf(&time.Time{}) {
+		v, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("pagination: unsupported column %s", field.DBName)
+}
+
+// Meta is the meta of a keyset paged list, the total is left out when it wasn't counted.
+func Meta(pageNumber int, limit int, total int64, page Page) map[string]interface{} {
+	meta := map[string]interface{}{
+		"page":        pageNumber,
+		"limit":       limit,
+		"next_cursor": page.NextCursor,
+		"prev_cursor": page.PrevCursor,
+	}
+
+	if total >= 0 {
+		meta["total"] = total
+	}
+
+	return meta
+}
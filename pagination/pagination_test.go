@@ -0,0 +1,294 @@
+package pagination
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+type row struct {
+	ID        int64 `gorm:"primaryKey"`
+	Name      string
+	Score     float64
+	CreatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// dryRun opens a database which only builds statements, on the table of row.
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		DryRun: true,
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db.Model(&row{})
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := &Cursor{Sort: "-name,-id", Values: []string{"card, with a comma", "42"}, Before: true}
+
+	got, err := DecodeCursor(c.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("DecodeCursor = %+v, want %+v", got, c)
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "%%%"},
+		{"not json", "bm90IGpzb24"},
+		{"no sort", (&Cursor{Values: []string{"1"}}).Encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestNewKeyset(t *testing.T) {
+	var (
+		byName   = []Key{{Column: "name", Desc: true}}
+		nameSort = &Cursor{Sort: "-name,-id", Values: []string{"b", "2"}}
+	)
+
+	tests := []struct {
+		name       string
+		keys       []Key
+		cursor     string
+		page       int
+		wantSort   string
+		wantOffset int
+		wantErr    error
+	}{
+		{"id by default", nil, "", 0, "id", 0, nil},
+		{"id follows the last key", byName, "", 0, "-name,-id", 0, nil},
+		{"keys after the id dropped", []Key{{Column: "id"}, {Column: "name"}}, "", 0, "id", 0, nil},
+		{"page turned into an offset", byName, "", 3, "-name,-id", 20, nil},
+		{"cursor of the order", byName, nameSort.Encode(), 3, "-name,-id", 0, nil},
+		{"cursor of another order", []Key{{Column: "name"}}, nameSort.Encode(), 0, "", 0, ErrInvalidCursor},
+		{"cursor missing a value", byName, (&Cursor{Sort: "-name,-id", Values: []string{"b"}}).Encode(), 0, "", 0, ErrInvalidCursor},
+		{"cursor garbled", byName, "garbled", 0, "", 0, ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := NewKeyset(tt.keys, tt.cursor, tt.page, 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewKeyset error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if k.signature() != tt.wantSort {
+				t.Errorf("signature = %q, want %q", k.signature(), tt.wantSort)
+			}
+
+			if k.Offset != tt.wantOffset {
+				t.Errorf("Offset = %d, want %d", k.Offset, tt.wantOffset)
+			}
+
+			if (k.Cursor != nil) != (tt.cursor != "") {
+				t.Errorf("Cursor = %+v for the cursor %q", k.Cursor, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	var (
+		name = clause.Column{Table: "rows", Name: "name"}
+		id   = clause.Column{Table: "rows", Name: "id"}
+	)
+
+	tests := []struct {
+		name     string
+		columns  []clause.Column
+		desc     []bool
+		values   []interface{}
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			"one key", []clause.Column{id}, []bool{false}, []interface{}{int64(2)},
+			"SELECT * FROM `rows` WHERE `rows`.`id` > ?",
+			[]interface{}{int64(2)},
+		},
+		{
+			"descending", []clause.Column{id}, []bool{true}, []interface{}{int64(2)},
+			"SELECT * FROM `rows` WHERE `rows`.`id` < ?",
+			[]interface{}{int64(2)},
+		},
+		{
+			"two keys", []clause.Column{name, id}, []bool{true, false}, []interface{}{"b", int64(2)},
+			"SELECT * FROM `rows` WHERE (`rows`.`name` < ? OR (`rows`.`name` = ? AND `rows`.`id` > ?))",
+			[]interface{}{"b", "b", int64(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []row
+
+			stmt := dryRun(t).Where(after(tt.columns, tt.desc, tt.values)).Find(&rows).Statement
+			if got := stmt.SQL.String(); got != tt.wantSQL {
+				t.Errorf("SQL = %s, want %s", got, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(stmt.Vars, tt.wantVars) {
+				t.Errorf("Vars = %v, want %v", stmt.Vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestFinish(t *testing.T) {
+	keys := []Key{{Column: "name"}}
+
+	rows := func(names ...string) []row {
+		rows := make([]row, 0, len(names))
+		for i := range names {
+			rows = append(rows, row{ID: int64(i + 1), Name: names[i]})
+		}
+
+		return rows
+	}
+
+	tests := []struct {
+		name     string
+		cursor   *Cursor
+		page     int
+		rows     []row
+		want     []string
+		wantNext string
+		wantPrev string
+	}{
+		{"first page", nil, 0, rows("a", "b", "c"), []string{"a", "b"}, "b", ""},
+		{"only page", nil, 0, rows("a", "b"), []string{"a", "b"}, "", ""},
+		{"page by offset", nil, 2, rows("c", "d"), []string{"c", "d"}, "", "c"},
+		{"after a cursor", &Cursor{}, 0, rows("c", "d", "e"), []string{"c", "d"}, "d", "c"},
+		{"last page after a cursor", &Cursor{}, 0, rows("c"), []string{"c"}, "", "c"},
+		{"before a cursor", &Cursor{Before: true}, 0, rows("d", "c", "b"), []string{"c", "d"}, "d", "c"},
+		{"first page before a cursor", &Cursor{Before: true}, 0, rows("b", "a"), []string{"a", "b"}, "b", ""},
+		{"nothing", nil, 0, nil, []string{}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := NewKeyset(keys, "", tt.page, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.cursor != nil {
+				k.Cursor = &Cursor{Sort: k.signature(), Values: []string{"x", "0"}, Before: tt.cursor.Before}
+			}
+
+			got, page, err := Finish(k, dryRun(t), tt.rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, 0, len(got))
+			for i := range got {
+				names = append(names, got[i].Name)
+			}
+
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("rows = %v, want %v", names, tt.want)
+			}
+
+			checkCursor(t, "next", k, page.NextCursor, tt.wantNext, false)
+			checkCursor(t, "prev", k, page.PrevCursor, tt.wantPrev, true)
+		})
+	}
+}
+
+// checkCursor tells whether cursor points after the row named want, or before it when before is set.
+func checkCursor(t *testing.T, which string, k *Keyset, cursor string, want string, before bool) {
+	t.Helper()
+
+	if want == "" {
+		if cursor != "" {
+			t.Errorf("%s cursor = %q, want none", which, cursor)
+		}
+
+		return
+	}
+
+	c, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("%s cursor: %v", which, err)
+	}
+
+	if c.Sort != k.signature() || c.Before != before || len(c.Values) != 2 || c.Values[0] != want {
+		t.Errorf("%s cursor = %+v, want it at %q with before %v", which, c, want, before)
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	db := dryRun(t)
+	if err := db.Statement.Parse(db.Statement.Model); err != nil {
+		t.Fatal(err)
+	}
+
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	tests := []struct {
+		name    string
+		column  string
+		value   string
+		want    interface{}
+		wantErr bool
+	}{
+		{"integer", "id", "42", int64(42), false},
+		{"not an integer", "id", "4x", nil, true},
+		{"string", "name", "card", "card", false},
+		{"time", "created_at", formatValue(createdAt), createdAt, false},
+		{"time pointer", "deleted_at", formatValue(&createdAt), createdAt, false},
+		{"not a time", "created_at", "yesterday", nil, true},
+		{"unsupported column", "score", "1.5", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseValue(db.Statement.Schema.LookUpField(tt.column), tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseValue error = %v, want an error: %v", err, tt.wantErr)
+			}
+
+			if want, ok := tt.want.(time.Time); ok {
+				if got, _ := got.(time.Time); !got.Equal(want) {
+					t.Errorf("parseValue = %v, want %v", got, want)
+				}
+
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("parseValue = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
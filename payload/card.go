@@ -1,14 +1,88 @@
 package payload
 
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateCardRequest fills the fields it leaves empty from the template given by TemplateId.
 type CreateCardRequest struct {
-	NameCard string `json:"name_card"`
-	CardType string `json:"card_type"`
-	UserId   int64  `json:"user_id"`
+	TemplateId int64           `json:"template_id"`
+	NameCard   string          `json:"name_card" validate:"required_without=template_id,max=255"`
+	CardType   string          `json:"card_type" validate:"required_without=template_id,max=255"`
+	Attributes json.RawMessage `json:"attributes"`
+	ActiveFrom *time.Time      `json:"active_from"`
+	ExpiresAt  *time.Time      `json:"expires_at"`
+	UserId     int64           `json:"-"`
+}
+
+const (
+	CardScopeOwned  = "owned"
+	CardScopeShared = "shared"
+	CardScopeAll    = "all"
+)
+
+const (
+	TagsModeAny = "any"
+	TagsModeAll = "all"
+)
+
+type GetListCardRequest struct {
+	GetListRequest
+	Scope    string `json:"scope,omitempty" query:"scope"`
+	Tags     string `json:"tags,omitempty" query:"tags"`
+	TagsMode string `json:"tags_mode,omitempty" query:"tags_mode"`
+	// IncludeInactive also lists the scheduled, expired and archived cards of the caller.
+	IncludeInactive bool `json:"include_inactive,omitempty" query:"include_inactive"`
+	// Attributes holds the `attr.<path>=<value>` query parameters, a path given several times matches any of its values.
+	Attributes map[string][]string `json:"-"`
+	UserId     int64               `json:"-"`
 }
 
 type UpdateCardRequest struct {
-	ID       int64   `json:"-"`
-	CardType *string `json:"card_type"`
-	NameCard *string `json:"name_card"`
-	UserId   int64   `json:"user_id"`
+	ID         int64           `json:"-"`
+	CardType   *string         `json:"card_type" validate:"notblank,max=255"`
+	NameCard   *string         `json:"name_card" validate:"notblank,max=255"`
+	Attributes json.RawMessage `json:"attributes"`
+	// ActiveFrom and ExpiresAt are left as is when missing, a json null removes them.
+	ActiveFrom json.RawMessage `json:"active_from"`
+	ExpiresAt  json.RawMessage `json:"expires_at"`
+	UserId     int64           `json:"-"`
+	// Version is the version the update was made from, given by If-Match, 0 updates any version.
+	Version int64 `json:"-"`
+}
+
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+type BatchCardOperation struct {
+	Op         string          `json:"op"`
+	ID         int64           `json:"id"`
+	NameCard   *string         `json:"name_card"`
+	CardType   *string         `json:"card_type"`
+	Attributes json.RawMessage `json:"attributes"`
+	// Version is the version an update was made from, the one the ETag of the card gives, 0 updates any version.
+	Version int64 `json:"version"`
+}
+
+type BatchCardRequest struct {
+	Mode       string               `json:"mode"`
+	Operations []BatchCardOperation `json:"operations"`
+	UserId     int64                `json:"-"`
+}
+
+type GetListCardTrashRequest struct {
+	GetListRequest
+	UserId int64 `json:"-"`
+}
+
+type RestoreCardRequest struct {
+	ID     int64 `json:"-"`
+	UserId int64 `json:"-"`
 }
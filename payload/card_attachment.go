@@ -0,0 +1,23 @@
+package payload
+
+import (
+	"mime/multipart"
+)
+
+type UploadCardAttachmentRequest struct {
+	CardId int64                 `json:"-"`
+	File   *multipart.FileHeader `json:"-"`
+	UserId int64                 `json:"-"`
+}
+
+type CardAttachmentRequest struct {
+	CardId       int64 `json:"-"`
+	AttachmentId int64 `json:"-"`
+	UserId       int64 `json:"-"`
+}
+
+type DownloadCardAttachmentRequest struct {
+	ID        int64  `json:"-"`
+	Expires   int64  `json:"expires" query:"expires"`
+	Signature string `json:"signature" query:"signature"`
+}
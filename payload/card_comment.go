@@ -0,0 +1,30 @@
+package payload
+
+// CreateCardCommentRequest replies to ParentId when it is set.
+type CreateCardCommentRequest struct {
+	CardId   int64  `json:"-"`
+	ParentId int64  `json:"parent_id"`
+	Body     string `json:"body"`
+	UserId   int64  `json:"-"`
+}
+
+type UpdateCardCommentRequest struct {
+	ID     int64  `json:"-"`
+	CardId int64  `json:"-"`
+	Body   string `json:"body"`
+	UserId int64  `json:"-"`
+}
+
+type DeleteCardCommentRequest struct {
+	ID     int64 `json:"-"`
+	CardId int64 `json:"-"`
+	UserId int64 `json:"-"`
+}
+
+// GetListCardCommentRequest lists the replies of ParentId, or the comments starting a thread when it is 0.
+type GetListCardCommentRequest struct {
+	GetListRequest
+	CardId   int64 `json:"-"`
+	ParentId int64 `json:"-"`
+	UserId   int64 `json:"-"`
+}
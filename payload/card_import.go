@@ -0,0 +1,37 @@
+package payload
+
+import (
+	"encoding/json"
+	"mime/multipart"
+)
+
+const (
+	CardFormatCSV  = "csv"
+	CardFormatJSON = "json"
+
+	DuplicateSkip      = "skip"
+	DuplicateOverwrite = "overwrite"
+	DuplicateCreate    = "create"
+)
+
+type ExportCardRequest struct {
+	Format string `json:"format" query:"format"`
+	UserId int64  `json:"-"`
+}
+
+type ImportCardRequest struct {
+	File        *multipart.FileHeader `json:"-"`
+	Format      string                `json:"format" query:"format" form:"format"`
+	DryRun      bool                  `json:"dry_run" query:"dry_run" form:"dry_run"`
+	OnDuplicate string                `json:"on_duplicate" query:"on_duplicate" form:"on_duplicate"`
+	UserId      int64                 `json:"-"`
+}
+
+// ImportCardRow is one card of an import file, a csv file carries the tags comma separated
+// and the attributes as a json object in one column each.
+type ImportCardRow struct {
+	NameCard   string          `json:"name_card"`
+	CardType   string          `json:"card_type"`
+	Attributes json.RawMessage `json:"attributes"`
+	Tags       []string        `json:"tags"`
+}
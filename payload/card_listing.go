@@ -0,0 +1,20 @@
+package payload
+
+type CreateCardListingRequest struct {
+	CardId int64 `json:"-"`
+	Price  int   `json:"price"`
+	UserId int64 `json:"-"`
+}
+
+type CardListingActionRequest struct {
+	ID     int64 `json:"-"`
+	UserId int64 `json:"-"`
+}
+
+type GetListCardListingRequest struct {
+	GetListRequest
+	CardType string `json:"card_type,omitempty" query:"card_type"`
+	Role     string `json:"role,omitempty" query:"role"`
+	Status   string `json:"status,omitempty" query:"status"`
+	UserId   int64  `json:"-"`
+}
@@ -0,0 +1,20 @@
+package payload
+
+type GetListCardRevisionRequest struct {
+	GetListRequest
+	CardId int64 `json:"-"`
+	UserId int64 `json:"-"`
+}
+
+type DiffCardRevisionRequest struct {
+	CardId int64 `json:"-"`
+	From   int   `json:"from" query:"from"`
+	To     int   `json:"to" query:"to"`
+	UserId int64 `json:"-"`
+}
+
+type RestoreCardRevisionRequest struct {
+	CardId   int64 `json:"-"`
+	Revision int   `json:"-"`
+	UserId   int64 `json:"-"`
+}
@@ -0,0 +1,19 @@
+package payload
+
+type ShareCardRequest struct {
+	CardId       int64  `json:"-"`
+	TargetUserId int64  `json:"user_id"`
+	Permission   string `json:"permission"`
+	UserId       int64  `json:"-"`
+}
+
+type GetListCardShareRequest struct {
+	CardId int64 `json:"-"`
+	UserId int64 `json:"-"`
+}
+
+type RevokeCardShareRequest struct {
+	CardId       int64 `json:"-"`
+	TargetUserId int64 `json:"-"`
+	UserId       int64 `json:"-"`
+}
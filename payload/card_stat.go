@@ -0,0 +1,15 @@
+package payload
+
+const (
+	StatsIntervalDay   = "day"
+	StatsIntervalWeek  = "week"
+	StatsIntervalMonth = "month"
+)
+
+// GetCardStatsRequest takes YYYY-MM-DD dates in the stats timezone, UserId 0 covers every user.
+type GetCardStatsRequest struct {
+	Interval string `json:"interval" query:"interval"`
+	From     string `json:"from" query:"from"`
+	To       string `json:"to" query:"to"`
+	UserId   int64  `json:"user_id" query:"user_id"`
+}
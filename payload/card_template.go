@@ -0,0 +1,28 @@
+package payload
+
+type DuplicateCardRequest struct {
+	ID int64 `json:"-"`
+	// NameCard defaults to the name of the original card.
+	NameCard string `json:"name_card"`
+	UserId   int64  `json:"-"`
+}
+
+// CreateCardTemplateRequest saves a card as template, only admins can make it global.
+type CreateCardTemplateRequest struct {
+	CardId int64  `json:"-"`
+	Name   string `json:"name"`
+	Global bool   `json:"global"`
+	UserId int64  `json:"-"`
+}
+
+const (
+	TemplateScopePersonal = "personal"
+	TemplateScopeGlobal   = "global"
+	TemplateScopeAll      = "all"
+)
+
+type GetListCardTemplateRequest struct {
+	GetListRequest
+	Scope  string `json:"scope,omitempty" query:"scope"`
+	UserId int64  `json:"-"`
+}
@@ -0,0 +1,19 @@
+package payload
+
+type CreateCardTransferRequest struct {
+	CardId   int64 `json:"-"`
+	ToUserId int64 `json:"to_user_id"`
+	UserId   int64 `json:"-"`
+}
+
+type RespondCardTransferRequest struct {
+	ID     int64 `json:"-"`
+	UserId int64 `json:"-"`
+}
+
+type GetListCardTransferRequest struct {
+	GetListRequest
+	Direction string `json:"direction,omitempty" query:"direction"`
+	Status    string `json:"status,omitempty" query:"status"`
+	UserId    int64  `json:"-"`
+}
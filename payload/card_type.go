@@ -0,0 +1,24 @@
+package payload
+
+import "encoding/json"
+
+type CreateCardTypeRequest struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	IsActive    *bool  `json:"is_active"`
+	// AttributesSchema is a JSON Schema the attributes of the cards of this type must match.
+	AttributesSchema json.RawMessage `json:"attributes_schema"`
+}
+
+type UpdateCardTypeRequest struct {
+	ID          int64   `json:"-"`
+	Code        *string `json:"code"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Icon        *string `json:"icon"`
+	IsActive    *bool   `json:"is_active"`
+	// AttributesSchema is left as is when missing, a json null removes it.
+	AttributesSchema json.RawMessage `json:"attributes_schema"`
+}
@@ -0,0 +1,69 @@
+package payload
+
+type CreateDeckRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	UserId      int64  `json:"-"`
+}
+
+type UpdateDeckRequest struct {
+	ID          int64   `json:"-"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	UserId      int64   `json:"-"`
+}
+
+// GetListDeckRequest takes the same scopes as the card list.
+type GetListDeckRequest struct {
+	GetListRequest
+	Scope  string `json:"scope,omitempty" query:"scope"`
+	UserId int64  `json:"-"`
+}
+
+type GetListDeckCardRequest struct {
+	GetListRequest
+	DeckId int64 `json:"-"`
+	UserId int64 `json:"-"`
+}
+
+// AddDeckCardRequest appends the card unless one of its future neighbours is given.
+type AddDeckCardRequest struct {
+	DeckId       int64 `json:"-"`
+	CardId       int64 `json:"card_id"`
+	AfterCardId  int64 `json:"after_card_id"`
+	BeforeCardId int64 `json:"before_card_id"`
+	UserId       int64 `json:"-"`
+}
+
+// MoveDeckCardRequest needs at least one of the new neighbours of the card.
+type MoveDeckCardRequest struct {
+	DeckId       int64 `json:"-"`
+	CardId       int64 `json:"-"`
+	AfterCardId  int64 `json:"after_card_id"`
+	BeforeCardId int64 `json:"before_card_id"`
+	UserId       int64 `json:"-"`
+}
+
+type RemoveDeckCardRequest struct {
+	DeckId int64 `json:"-"`
+	CardId int64 `json:"-"`
+	UserId int64 `json:"-"`
+}
+
+type ShareDeckRequest struct {
+	DeckId       int64  `json:"-"`
+	TargetUserId int64  `json:"user_id"`
+	Permission   string `json:"permission"`
+	UserId       int64  `json:"-"`
+}
+
+type GetListDeckShareRequest struct {
+	DeckId int64 `json:"-"`
+	UserId int64 `json:"-"`
+}
+
+type RevokeDeckShareRequest struct {
+	DeckId       int64 `json:"-"`
+	TargetUserId int64 `json:"-"`
+	UserId       int64 `json:"-"`
+}
@@ -1,22 +1,39 @@
 package payload
 
-import "strings"
+import (
+	"net/url"
+	"strings"
+)
 
 type GetByIDRequest struct {
 	ID int64 `json:"-"`
 }
 
-var orderBy = []string{"id", "created_by", "updated_by"}
+var orderBy = []string{"id", "created_at", "updated_at"}
 
+// GetListRequest pages either with Page or, on the lists which support it, with the opaque Cursor
+// of a previous response. SkipCount leaves the total out of the response.
+// Sort and Filters are checked against the field registry of the list, OrderBy is the older form of Sort.
 type GetListRequest struct {
-	Page    int    `json:"page" query:"page"`
-	Limit   int    `json:"limit" query:"limit"`
-	OrderBy string `json:"order_by,omitempty" query:"order_by"`
-	Search  string `json:"search,omitempty" query:"search"`
+	Page      int    `json:"page" query:"page"`
+	Limit     int    `json:"limit" query:"limit"`
+	OrderBy   string `json:"order_by,omitempty" query:"order_by"`
+	Sort      string `json:"sort,omitempty" query:"sort"`
+	Search    string `json:"search,omitempty" query:"search"`
+	Cursor    string `json:"cursor,omitempty" query:"cursor"`
+	SkipCount bool   `json:"skip_count,omitempty" query:"skip_count"`
+	Fields    string `json:"fields,omitempty" query:"fields"`
+	Include   string `json:"include,omitempty" query:"include"`
+	// IncludeSet tells `include=`, which includes nothing, from a missing include which keeps the defaults.
+	IncludeSet bool `json:"-"`
+	// Filters holds the `filter[<field>][<op>]` query parameters keyed by field then operator.
+	Filters map[string]map[string]string `json:"-"`
 }
 
 func (g *GetListRequest) Format() {
 	g.Search = strings.TrimSpace(g.Search)
+	g.Cursor = strings.TrimSpace(g.Cursor)
+	g.Sort = strings.TrimSpace(g.Sort)
 	g.OrderBy = strings.ToLower(strings.TrimSpace(g.OrderBy))
 
 	for i := range orderBy {
@@ -28,8 +45,35 @@ func (g *GetListRequest) Format() {
 	g.OrderBy = ""
 }
 
+// FilterParams collects the `filter[<field>][<op>]` query parameters, `filter[<field>]` compares with eq.
+// Anything after the field is kept as operator, the registry of the list rejects what it doesn't know.
+func FilterParams(params url.Values) map[string]map[string]string {
+	filters := make(map[string]map[string]string)
+
+	for key := range params {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+
+		parts := strings.SplitN(strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]"), "][", 2)
+		op := "eq"
+		if len(parts) == 2 {
+			op = parts[1]
+		}
+
+		if filters[parts[0]] == nil {
+			filters[parts[0]] = make(map[string]string)
+		}
+
+		filters[parts[0]][op] = params.Get(key)
+	}
+
+	return filters
+}
+
 type DeleteRequest struct {
-	ID int64 `json:"-"`
+	ID        int64 `json:"-"`
+	Permanent bool  `json:"-"`
 }
 
 type SignInRequest struct {
@@ -0,0 +1,18 @@
+package payload
+
+type AttachCardTagsRequest struct {
+	CardId int64    `json:"-"`
+	Tags   []string `json:"tags"`
+	UserId int64    `json:"-"`
+}
+
+type DetachCardTagRequest struct {
+	CardId int64 `json:"-"`
+	TagId  int64 `json:"-"`
+	UserId int64 `json:"-"`
+}
+
+type GetListTagRequest struct {
+	GetListRequest
+	UserId int64 `json:"-"`
+}
@@ -1,17 +1,30 @@
 package payload
 
+import (
+	"reflect"
+
+	"myapp/validation"
+)
+
+func init() {
+	// bcrypt refuses the passwords longer than 72 bytes
+	validation.Register("bcrypt", func(value reflect.Value, _ string, _ reflect.Value) bool {
+		return len(value.String()) <= 72
+	}, "must be at most 72 bytes long")
+}
+
 type CreateUserRequest struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Score    int    `json:"score"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Email    string `json:"email" validate:"required,max=255,email"`
+	Username string `json:"username" validate:"required,max=255"`
+	Password string `json:"password" validate:"required,bcrypt"`
 }
 
 type UpdateUserRequest struct {
 	ID       int64   `json:"-"`
-	Name     *string `json:"name"`
-	Score    *int    `json:"score"`
-	Username *string `json:"username"`
-	Email    *string `json:"email"`
+	Name     *string `json:"name" validate:"notblank,max=255"`
+	Username *string `json:"username" validate:"notblank,max=255"`
+	Email    *string `json:"email" validate:"notblank,max=255,email"`
+	// Version comes from If-Match, 0 skips the check.
+	Version int64 `json:"-"`
 }
@@ -0,0 +1,60 @@
+package policy
+
+import (
+	"time"
+
+	"myapp/customError"
+	"myapp/model"
+)
+
+const KindCard = "card"
+
+const (
+	cardRoleViewer = model.CardPermissionViewer
+	cardRoleEditor = model.CardPermissionEditor
+	cardRoleOwner  = model.CardPermissionOwner
+)
+
+// CardResource is a card together with the permission the principal was shared, if any.
+type CardResource struct {
+	Card       *model.Card
+	SharedWith string
+	Now        time.Time
+}
+
+func NewCardResource(card *model.Card, sharedWith string) *CardResource {
+	return &CardResource{Card: card, SharedWith: sharedWith, Now: time.Now()}
+}
+
+func (r *CardResource) Kind() string {
+	return KindCard
+}
+
+// role returns the permission the principal holds on the card, or an empty string if the card is hidden from them.
+func (r *CardResource) role(p Principal) string {
+	if r.Card.UserId == p.UserId {
+		return cardRoleOwner
+	}
+
+	// scheduled and expired cards only show up for their owner
+	if !r.Card.IsActive(r.Now) {
+		return ""
+	}
+
+	return r.SharedWith
+}
+
+func cardRule(required string) Rule {
+	return func(p Principal, r Resource) error {
+		role := r.(*CardResource).role(p)
+		if role == "" {
+			return customError.ErrModelNotFound()
+		}
+
+		if !model.HasCardPermission(role, required) {
+			return customError.ErrNoPermission()
+		}
+
+		return nil
+	}
+}
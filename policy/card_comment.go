@@ -0,0 +1,39 @@
+package policy
+
+import (
+	"myapp/customError"
+	"myapp/model"
+)
+
+const KindCardComment = "card_comment"
+
+// CardCommentResource only covers changes to a comment, reading and writing comments follows the card.
+type CardCommentResource struct {
+	Comment     *model.CardComment
+	CardOwnerId int64
+}
+
+func NewCardCommentResource(comment *model.CardComment, cardOwnerId int64) *CardCommentResource {
+	return &CardCommentResource{Comment: comment, CardOwnerId: cardOwnerId}
+}
+
+func (r *CardCommentResource) Kind() string {
+	return KindCardComment
+}
+
+func commentAuthor(p Principal, r Resource) error {
+	if r.(*CardCommentResource).Comment.UserId != p.UserId {
+		return customError.ErrNoPermission()
+	}
+
+	return nil
+}
+
+// commentAuthorOrCardOwner lets card owners moderate the comments on their cards.
+func commentAuthorOrCardOwner(p Principal, r Resource) error {
+	if r.(*CardCommentResource).CardOwnerId == p.UserId {
+		return nil
+	}
+
+	return commentAuthor(p, r)
+}
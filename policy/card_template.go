@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"myapp/customError"
+	"myapp/model"
+)
+
+const KindCardTemplate = "card_template"
+
+type CardTemplateResource struct {
+	Template *model.CardTemplate
+}
+
+func NewCardTemplateResource(template *model.CardTemplate) *CardTemplateResource {
+	return &CardTemplateResource{Template: template}
+}
+
+func (r *CardTemplateResource) Kind() string {
+	return KindCardTemplate
+}
+
+// templateCreate leaves the global templates to admins.
+func templateCreate(p Principal, r Resource) error {
+	if r.(*CardTemplateResource).Template.IsGlobal && !p.IsAdmin {
+		return customError.ErrNoPermission()
+	}
+
+	return nil
+}
+
+func templateView(p Principal, r Resource) error {
+	template := r.(*CardTemplateResource).Template
+	if !template.IsGlobal && template.UserId != p.UserId {
+		return customError.ErrModelNotFound()
+	}
+
+	return nil
+}
+
+func templateDelete(p Principal, r Resource) error {
+	if err := templateView(p, r); err != nil {
+		return err
+	}
+
+	template := r.(*CardTemplateResource).Template
+	if template.UserId != p.UserId && !p.IsAdmin {
+		return customError.ErrNoPermission()
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package policy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"myapp/appError"
+	"myapp/model"
+)
+
+// status is the HTTP status Can answers with, 0 when the action is allowed.
+func status(t *testing.T, err error) int {
+	t.Helper()
+
+	if err == nil {
+		return 0
+	}
+
+	teqErr, ok := err.(appError.TeqError)
+	if !ok {
+		t.Fatalf("expected a TeqError, got %T: %v", err, err)
+	}
+
+	return teqErr.HTTPCode
+}
+
+func TestCanCard(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Hour), now.Add(time.Hour)
+
+	owner := Principal{UserId: 1}
+	other := Principal{UserId: 2}
+	admin := Principal{UserId: 3, IsAdmin: true}
+
+	active := &model.Card{UserId: owner.UserId}
+	scheduled := &model.Card{UserId: owner.UserId, ActiveFrom: &after}
+	expired := &model.Card{UserId: owner.UserId, ExpiresAt: &before}
+	archived := &model.Card{UserId: owner.UserId, ArchivedAt: &before}
+
+	tests := []struct {
+		name       string
+		principal  Principal
+		action     Action
+		card       *model.Card
+		sharedWith string
+		want       int
+	}{
+		{"owner views", owner, ActionView, active, "", 0},
+		{"owner updates", owner, ActionUpdate, active, "", 0},
+		{"owner deletes", owner, ActionDelete, active, "", 0},
+		{"owner shares", owner, ActionShare, active, "", 0},
+		{"owner restores", owner, ActionRestore, active, "", 0},
+		{"owner views scheduled", owner, ActionView, scheduled, "", 0},
+		{"owner views expired", owner, ActionView, expired, "", 0},
+		{"owner views archived", owner, ActionView, archived, "", 0},
+		{"owner creates", owner, ActionCreate, active, "", http.StatusForbidden},
+
+		{"stranger views", other, ActionView, active, "", http.StatusNotFound},
+		{"stranger updates", other, ActionUpdate, active, "", http.StatusNotFound},
+		{"admin views", admin, ActionView, active, "", http.StatusNotFound},
+
+		{"viewer views", other, ActionView, active, model.CardPermissionViewer, 0},
+		{"viewer updates", other, ActionUpdate, active, model.CardPermissionViewer, http.StatusForbidden},
+		{"viewer deletes", other, ActionDelete, active, model.CardPermissionViewer, http.StatusForbidden},
+
+		{"editor views", other, ActionView, active, model.CardPermissionEditor, 0},
+		{"editor updates", other, ActionUpdate, active, model.CardPermissionEditor, 0},
+		{"editor deletes", other, ActionDelete, active, model.CardPermissionEditor, http.StatusForbidden},
+		{"editor shares", other, ActionShare, active, model.CardPermissionEditor, http.StatusForbidden},
+		{"editor restores", other, ActionRestore, active, model.CardPermissionEditor, http.StatusForbidden},
+
+		{"shared owner deletes", other, ActionDelete, active, model.CardPermissionOwner, 0},
+		{"shared owner shares", other, ActionShare, active, model.CardPermissionOwner, 0},
+
+		{"editor views scheduled", other, ActionView, scheduled, model.CardPermissionEditor, http.StatusNotFound},
+		{"editor views expired", other, ActionView, expired, model.CardPermissionEditor, http.StatusNotFound},
+		{"editor views archived", other, ActionView, archived, model.CardPermissionEditor, http.StatusNotFound},
+		{"unknown permission views", other, ActionView, active, "admin", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := &CardResource{Card: tt.card, SharedWith: tt.sharedWith, Now: now}
+			if got := status(t, Can(tt.principal, tt.action, resource)); got != tt.want {
+				t.Errorf("Can(%s) = %d, want %d", tt.action, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"context"
+
+	"myapp/customError"
+)
+
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionView    Action = "view"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionShare   Action = "share"
+	ActionRestore Action = "restore"
+)
+
+// Principal is the user an action is authorized for.
+type Principal struct {
+	UserId  int64
+	IsAdmin bool
+}
+
+// PrincipalFromContext reads the user stored by the auth middleware.
+func PrincipalFromContext(ctx context.Context) Principal {
+	userId, _ := ctx.Value("user_id").(int64)
+	isAdmin, _ := ctx.Value("is_admin").(bool)
+
+	return Principal{UserId: userId, IsAdmin: isAdmin}
+}
+
+// Resource is anything the rules are written for, Kind picks its rule set.
+type Resource interface {
+	Kind() string
+}
+
+// Rule returns nil when the principal may act on the resource, it never needs the database.
+type Rule func(p Principal, r Resource) error
+
+// Can checks the action against the rule table. Actions without a rule are denied,
+// resources the principal can't see at all are reported as not found.
+func Can(p Principal, action Action, resource Resource) error {
+	rule, ok := rules[resource.Kind()][action]
+	if !ok {
+		return customError.ErrNoPermission()
+	}
+
+	return rule(p, resource)
+}
+
+var rules = map[string]map[Action]Rule{
+	KindCard: {
+		ActionView:    cardRule(cardRoleViewer),
+		ActionUpdate:  cardRule(cardRoleEditor),
+		ActionDelete:  cardRule(cardRoleOwner),
+		ActionShare:   cardRule(cardRoleOwner),
+		ActionRestore: cardRule(cardRoleOwner),
+	},
+	KindCardComment: {
+		ActionUpdate: commentAuthor,
+		ActionDelete: commentAuthorOrCardOwner,
+	},
+	KindCardTemplate: {
+		ActionCreate: templateCreate,
+		ActionView:   templateView,
+		ActionDelete: templateDelete,
+	},
+	KindUser: {
+		ActionView:   anyUser,
+		ActionUpdate: selfOrAdmin,
+		ActionDelete: selfOrAdmin,
+	},
+}
@@ -0,0 +1,37 @@
+package policy
+
+import (
+	"myapp/customError"
+	"myapp/model"
+)
+
+const KindUser = "user"
+
+type UserResource struct {
+	User *model.User
+}
+
+func NewUserResource(user *model.User) *UserResource {
+	return &UserResource{User: user}
+}
+
+func (r *UserResource) Kind() string {
+	return KindUser
+}
+
+// anyUser lets every signed in user see public profiles.
+func anyUser(p Principal, _ Resource) error {
+	if p.UserId == 0 {
+		return customError.ErrNoPermission()
+	}
+
+	return nil
+}
+
+func selfOrAdmin(p Principal, r Resource) error {
+	if p.IsAdmin || r.(*UserResource).User.ID == p.UserId {
+		return nil
+	}
+
+	return customError.ErrNoPermission()
+}
@@ -0,0 +1,44 @@
+package policy
+
+import (
+	"net/http"
+	"testing"
+
+	"myapp/model"
+)
+
+func TestCanUser(t *testing.T) {
+	user := &model.User{}
+	user.ID = 1
+
+	self := Principal{UserId: 1}
+	other := Principal{UserId: 2}
+	admin := Principal{UserId: 3, IsAdmin: true}
+	anonymous := Principal{}
+
+	tests := []struct {
+		name      string
+		principal Principal
+		action    Action
+		want      int
+	}{
+		{"self views", self, ActionView, 0},
+		{"self updates", self, ActionUpdate, 0},
+		{"self deletes", self, ActionDelete, 0},
+		{"other views", other, ActionView, 0},
+		{"other updates", other, ActionUpdate, http.StatusForbidden},
+		{"other deletes", other, ActionDelete, http.StatusForbidden},
+		{"admin updates", admin, ActionUpdate, 0},
+		{"admin deletes", admin, ActionDelete, 0},
+		{"anonymous views", anonymous, ActionView, http.StatusForbidden},
+		{"self shares", self, ActionShare, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status(t, Can(tt.principal, tt.action, NewUserResource(user))); got != tt.want {
+				t.Errorf("Can(%s) = %d, want %d", tt.action, got, tt.want)
+			}
+		})
+	}
+}
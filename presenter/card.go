@@ -1,6 +1,8 @@
 package presenter
 
 import (
+	"encoding/json"
+
 	"myapp/model"
 )
 
@@ -11,4 +13,49 @@ type CardResponseWrapper struct {
 type ListCardResponseWrapper struct {
 	Cards []model.Card `json:"cards"`
 	Meta  interface{}  `json:"meta"`
+	// Fields trims every card to these keys when set.
+	Fields []string `json:"-"`
+}
+
+func (w ListCardResponseWrapper) MarshalJSON() ([]byte, error) {
+	type plain ListCardResponseWrapper
+	if w.Fields == nil {
+		return json.Marshal(plain(w))
+	}
+
+	cards, err := sparse(w.Cards, w.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{"cards": cards, "meta": w.Meta})
+}
+
+const (
+	BatchStatusOK         = "ok"
+	BatchStatusError      = "error"
+	BatchStatusRolledBack = "rolled_back"
+	BatchStatusSkipped    = "skipped"
+)
+
+type BatchError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type BatchCardResult struct {
+	Index  int         `json:"index"`
+	Op     string      `json:"op"`
+	ID     int64       `json:"id,omitempty"`
+	Status string      `json:"status"`
+	Card   *model.Card `json:"card,omitempty"`
+	Error  *BatchError `json:"error,omitempty"`
+}
+
+type BatchCardResponseWrapper struct {
+	Mode      string            `json:"mode"`
+	Committed bool              `json:"committed"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchCardResult `json:"results"`
 }
@@ -0,0 +1,21 @@
+package presenter
+
+import (
+	"io"
+
+	"myapp/model"
+)
+
+type CardAttachmentResponseWrapper struct {
+	Attachment *model.CardAttachment `json:"attachment"`
+}
+
+type ListCardAttachmentResponseWrapper struct {
+	Attachments []model.CardAttachment `json:"attachments"`
+}
+
+// CardAttachmentFileWrapper is streamed as is, the caller must close Content.
+type CardAttachmentFileWrapper struct {
+	Attachment *model.CardAttachment
+	Content    io.ReadCloser
+}
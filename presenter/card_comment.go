@@ -0,0 +1,14 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type CardCommentResponseWrapper struct {
+	Comment *model.CardComment `json:"comment"`
+}
+
+type ListCardCommentResponseWrapper struct {
+	Comments []model.CardComment `json:"comments"`
+	Meta     interface{}         `json:"meta"`
+}
@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"io"
+	"time"
+
+	"myapp/model"
+)
+
+// CardExportFileWrapper streams the export once the response headers are sent.
+type CardExportFileWrapper struct {
+	FileName    string
+	ContentType string
+	Write       func(w io.Writer) error
+}
+
+type CardExportRow struct {
+	ID         int64      `json:"id"`
+	NameCard   string     `json:"name_card"`
+	CardType   string     `json:"card_type"`
+	Attributes model.JSON `json:"attributes"`
+	Tags       []string   `json:"tags"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionSkip   = "skip"
+)
+
+type ImportCardResult struct {
+	Row    int         `json:"row"`
+	Action string      `json:"action,omitempty"`
+	CardId int64       `json:"card_id,omitempty"`
+	Error  *BatchError `json:"error,omitempty"`
+}
+
+type ImportCardResponseWrapper struct {
+	DryRun  bool               `json:"dry_run"`
+	Total   int                `json:"total"`
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Skipped int                `json:"skipped"`
+	Failed  int                `json:"failed"`
+	Results []ImportCardResult `json:"results"`
+}
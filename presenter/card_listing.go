@@ -0,0 +1,14 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type CardListingResponseWrapper struct {
+	Listing *model.CardListing `json:"listing"`
+}
+
+type ListCardListingResponseWrapper struct {
+	Listings []model.CardListing `json:"listings"`
+	Meta     interface{}         `json:"meta"`
+}
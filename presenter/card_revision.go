@@ -0,0 +1,22 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type ListCardRevisionResponseWrapper struct {
+	Revisions []model.CardRevision `json:"revisions"`
+	Meta      interface{}          `json:"meta"`
+}
+
+type CardRevisionChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type CardRevisionDiffResponseWrapper struct {
+	From    int                  `json:"from"`
+	To      int                  `json:"to"`
+	Changes []CardRevisionChange `json:"changes"`
+}
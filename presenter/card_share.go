@@ -0,0 +1,13 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type CardShareResponseWrapper struct {
+	Share *model.CardShare `json:"share"`
+}
+
+type ListCardShareResponseWrapper struct {
+	Shares []model.CardShare `json:"shares"`
+}
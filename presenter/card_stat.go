@@ -0,0 +1,19 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type CardTrendPoint struct {
+	Period string `json:"period"`
+	Count  int64  `json:"count"`
+}
+
+type CardStatsResponseWrapper struct {
+	Timezone string                `json:"timezone"`
+	Interval string                `json:"interval"`
+	From     string                `json:"from"`
+	To       string                `json:"to"`
+	ByType   []model.CardTypeCount `json:"by_type"`
+	Trend    []CardTrendPoint      `json:"trend"`
+}
@@ -0,0 +1,14 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type CardTemplateResponseWrapper struct {
+	Template *model.CardTemplate `json:"template"`
+}
+
+type ListCardTemplateResponseWrapper struct {
+	Templates []model.CardTemplate `json:"templates"`
+	Meta      interface{}          `json:"meta"`
+}
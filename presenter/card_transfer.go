@@ -0,0 +1,14 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type CardTransferResponseWrapper struct {
+	Transfer *model.CardTransfer `json:"transfer"`
+}
+
+type ListCardTransferResponseWrapper struct {
+	Transfers []model.CardTransfer `json:"transfers"`
+	Meta      interface{}          `json:"meta"`
+}
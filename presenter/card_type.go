@@ -0,0 +1,14 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type CardTypeResponseWrapper struct {
+	CardType *model.CardType `json:"card_type"`
+}
+
+type ListCardTypeResponseWrapper struct {
+	CardTypes []model.CardType `json:"card_types"`
+	Meta      interface{}      `json:"meta"`
+}
@@ -0,0 +1,31 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type DeckResponseWrapper struct {
+	Deck *model.Deck `json:"deck"`
+}
+
+type ListDeckResponseWrapper struct {
+	Decks []model.Deck `json:"decks"`
+	Meta  interface{}  `json:"meta"`
+}
+
+type DeckCardResponseWrapper struct {
+	DeckCard *model.DeckCard `json:"deck_card"`
+}
+
+type ListDeckCardResponseWrapper struct {
+	Cards []model.DeckCard `json:"cards"`
+	Meta  interface{}      `json:"meta"`
+}
+
+type DeckShareResponseWrapper struct {
+	Share *model.DeckShare `json:"share"`
+}
+
+type ListDeckShareResponseWrapper struct {
+	Shares []model.DeckShare `json:"shares"`
+}
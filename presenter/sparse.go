@@ -0,0 +1,37 @@
+package presenter
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// sparse marshals every row of the rows slice and keeps only the keys asked for.
+func sparse(rows interface{}, keys []string) ([]map[string]json.RawMessage, error) {
+	var (
+		value  = reflect.ValueOf(rows)
+		result = make([]map[string]json.RawMessage, 0, value.Len())
+	)
+
+	for i := 0; i < value.Len(); i++ {
+		raw, err := json.Marshal(value.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		var full map[string]json.RawMessage
+		if err = json.Unmarshal(raw, &full); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]json.RawMessage, len(keys))
+		for _, key := range keys {
+			if v, ok := full[key]; ok {
+				row[key] = v
+			}
+		}
+
+		result = append(result, row)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,10 @@
+package presenter
+
+import (
+	"myapp/model"
+)
+
+type ListTagResponseWrapper struct {
+	Tags []model.Tag `json:"tags"`
+	Meta interface{} `json:"meta"`
+}
@@ -1,6 +1,8 @@
 package presenter
 
 import (
+	"encoding/json"
+
 	"myapp/model"
 )
 
@@ -11,6 +13,22 @@ type UserResponseWrapper struct {
 type ListUserResponseWrapper struct {
 	Users []model.User `json:"users"`
 	Meta  interface{}  `json:"meta"`
+	// Fields trims every user to these keys when set.
+	Fields []string `json:"-"`
+}
+
+func (w ListUserResponseWrapper) MarshalJSON() ([]byte, error) {
+	type plain ListUserResponseWrapper
+	if w.Fields == nil {
+		return json.Marshal(plain(w))
+	}
+
+	users, err := sparse(w.Users, w.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{"users": users, "meta": w.Meta})
 }
 
 type SignUpResponseWrapper struct {
@@ -0,0 +1,24 @@
+package query
+
+const (
+	RelationCardUser = "user"
+	RelationCardTags = "tags"
+)
+
+// Cards are the fields and relations of the card list.
+var Cards = NewRegistry(
+	Field{Name: "id", Column: "id", Type: TypeInt, Sortable: true, Filterable: true},
+	Field{Name: "name_card", Column: "name_card", Type: TypeString, Sortable: true, Filterable: true},
+	Field{Name: "card_type", Column: "card_type", Type: TypeString, Filterable: true},
+	Field{Name: "attributes", Column: "attributes", Type: TypeJSON},
+	Field{Name: "comment_count", Column: "comment_count", Type: TypeInt, Sortable: true, Filterable: true},
+	Field{Name: "user_id", Column: "user_id", Type: TypeInt, Filterable: true},
+	Field{Name: "active_from", Column: "active_from", Type: TypeTime, Filterable: true},
+	Field{Name: "expires_at", Column: "expires_at", Type: TypeTime, Filterable: true},
+	Field{Name: "archived_at", Column: "archived_at", Type: TypeTime},
+	Field{Name: "created_at", Column: "created_at", Type: TypeTime, Sortable: true, Filterable: true},
+	Field{Name: "updated_at", Column: "updated_at", Type: TypeTime, Sortable: true, Filterable: true},
+).WithRelations(
+	Relation{Name: RelationCardUser, PerParent: 1, Default: true},
+	Relation{Name: RelationCardTags, PerParent: 20, Default: true},
+)
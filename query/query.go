@@ -0,0 +1,258 @@
+package query
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/clause"
+	"myapp/customError"
+	"myapp/pagination"
+)
+
+type Type string
+
+const (
+	TypeString Type = "string"
+	TypeInt    Type = "integer"
+	TypeTime   Type = "time"
+	TypeBool   Type = "boolean"
+	// TypeJSON fields can only be selected.
+	TypeJSON Type = "object"
+)
+
+const (
+	OpEq   = "eq"
+	OpNe   = "ne"
+	OpIn   = "in"
+	OpGt   = "gt"
+	OpGte  = "gte"
+	OpLt   = "lt"
+	OpLte  = "lte"
+	OpLike = "like"
+)
+
+const (
+	// maxSortKeys bounds the order of a list, the id is always added as last key.
+	maxSortKeys = 3
+	maxInValues = 100
+)
+
+// operators lists the filter operators of every field type.
+var operators = map[Type][]string{
+	TypeString: {OpEq, OpNe, OpIn, OpLike},
+	TypeInt:    {OpEq, OpNe, OpIn, OpGt, OpGte, OpLt, OpLte},
+	TypeTime:   {OpEq, OpGt, OpGte, OpLt, OpLte},
+	TypeBool:   {OpEq},
+}
+
+var comparisons = map[string]string{
+	OpEq:  "=",
+	OpNe:  "<>",
+	OpGt:  ">",
+	OpGte: ">=",
+	OpLt:  "<",
+	OpLte: "<=",
+}
+
+// Field is a field of the rows of a list, Column is the database column on the table of the list.
+// Every field can be selected, only columns which are never null can be sortable since the list pages on them.
+type Field struct {
+	Name       string
+	Column     string
+	Type       Type
+	Sortable   bool
+	Filterable bool
+}
+
+// Operators returns the filter operators of the field, none if it can't be filtered on.
+func (f Field) Operators() []string {
+	if !f.Filterable {
+		return nil
+	}
+
+	return operators[f.Type]
+}
+
+// Registry declares the fields and relations of one resource list.
+type Registry struct {
+	fields          []Field
+	byName          map[string]Field
+	relations       []Relation
+	relationsByName map[string]Relation
+}
+
+func NewRegistry(fields ...Field) *Registry {
+	r := &Registry{
+		fields:          fields,
+		byName:          make(map[string]Field, len(fields)),
+		relationsByName: make(map[string]Relation),
+	}
+
+	for i := range fields {
+		r.byName[fields[i].Name] = fields[i]
+	}
+
+	return r
+}
+
+// WithRelations declares the relations the list can include.
+func (r *Registry) WithRelations(relations ...Relation) *Registry {
+	r.relations = append(r.relations, relations...)
+	for i := range relations {
+		r.relationsByName[relations[i].Name] = relations[i]
+	}
+
+	return r
+}
+
+func (r *Registry) Fields() []Field {
+	return r.fields
+}
+
+func (r *Registry) Relations() []Relation {
+	return r.relations
+}
+
+func (r *Registry) lookup(name string) (Field, error) {
+	field, ok := r.byName[name]
+	if !ok {
+		return field, customError.ErrUnknownField(name)
+	}
+
+	return field, nil
+}
+
+// ParseSort reads `-created_at,name_card`, a leading minus sorts descending. An empty sort orders by id.
+func (r *Registry) ParseSort(s string) ([]pagination.Key, error) {
+	keys := make([]pagination.Key, 0)
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+
+		field, err := r.lookup(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !field.Sortable || seen[name] || len(keys) == maxSortKeys {
+			return nil, customError.ErrRequestInvalidParam("sort")
+		}
+
+		seen[name] = true
+		keys = append(keys, pagination.Key{Column: field.Column, Desc: desc})
+	}
+
+	return keys, nil
+}
+
+// ParseFilters turns filters, keyed by field then operator, into conditions on the list.
+func (r *Registry) ParseFilters(filters map[string]map[string]string) ([]clause.Expression, error) {
+	names := make([]string, 0, len(filters))
+	for name := range filters {
+		names = append(names, name)
+	}
+
+	// conditions come out in the same order for the same filters
+	sort.Strings(names)
+
+	conditions := make([]clause.Expression, 0, len(filters))
+	for _, name := range names {
+		field, err := r.lookup(name)
+		if err != nil {
+			return nil, err
+		}
+
+		ops := make([]string, 0, len(filters[name]))
+		for op := range filters[name] {
+			ops = append(ops, op)
+		}
+
+		sort.Strings(ops)
+
+		for _, op := range ops {
+			condition, err := field.condition(op, filters[name][op])
+			if err != nil {
+				return nil, err
+			}
+
+			conditions = append(conditions, condition)
+		}
+	}
+
+	return conditions, nil
+}
+
+func (f Field) condition(op string, raw string) (clause.Expression, error) {
+	var (
+		column  = clause.Column{Table: clause.CurrentTable, Name: f.Column}
+		invalid = customError.ErrRequestInvalidParam("filter[" + f.Name + "][" + op + "]")
+		allowed bool
+	)
+
+	for _, candidate := range f.Operators() {
+		allowed = allowed || candidate == op
+	}
+
+	if !allowed {
+		return nil, invalid
+	}
+
+	switch op {
+	case OpIn:
+		parts := strings.Split(raw, ",")
+		if len(parts) > maxInValues {
+			return nil, invalid
+		}
+
+		values := make([]interface{}, 0, len(parts))
+		for i := range parts {
+			value, err := f.parse(strings.TrimSpace(parts[i]))
+			if err != nil {
+				return nil, invalid
+			}
+
+			values = append(values, value)
+		}
+
+		return clause.IN{Column: column, Values: values}, nil
+	case OpLike:
+		// the wildcards of the value match themselves
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(raw)
+
+		return clause.Like{Column: column, Value: "%" + escaped + "%"}, nil
+	default:
+		value, err := f.parse(raw)
+		if err != nil {
+			return nil, invalid
+		}
+
+		return clause.Expr{SQL: "? " + comparisons[op] + " ?", Vars: []interface{}{column, value}}, nil
+	}
+}
+
+// parse reads a filter value, times are RFC 3339 or a plain date.
+func (f Field) parse(raw string) (interface{}, error) {
+	switch f.Type {
+	case TypeInt:
+		return strconv.ParseInt(raw, 10, 64)
+	case TypeBool:
+		return strconv.ParseBool(raw)
+	case TypeTime:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+
+		return time.Parse("2006-01-02", raw)
+	default:
+		return raw, nil
+	}
+}
@@ -0,0 +1,139 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/clause"
+	"myapp/appError"
+	"myapp/pagination"
+)
+
+var items = NewRegistry(
+	Field{Name: "id", Column: "id", Type: TypeInt, Sortable: true, Filterable: true},
+	Field{Name: "name", Column: "item_name", Type: TypeString, Sortable: true, Filterable: true},
+	Field{Name: "kind", Column: "kind", Type: TypeString, Filterable: true},
+	Field{Name: "done", Column: "done", Type: TypeBool, Filterable: true},
+	Field{Name: "created_at", Column: "created_at", Type: TypeTime, Sortable: true, Filterable: true},
+	Field{Name: "score", Column: "score", Type: TypeInt, Sortable: true},
+	Field{Name: "extra", Column: "extra", Type: TypeJSON},
+)
+
+// errorCode is the code of the error the parser refused with, empty when it accepted.
+func errorCode(t *testing.T, err error) string {
+	t.Helper()
+
+	if err == nil {
+		return ""
+	}
+
+	teqErr, ok := err.(appError.TeqError)
+	if !ok {
+		t.Fatalf("expected a TeqError, got %T: %v", err, err)
+	}
+
+	return teqErr.ErrorCode
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name string
+		sort string
+		want []pagination.Key
+		code string
+	}{
+		{"empty", "", []pagination.Key{}, ""},
+		{"ascending", "name", []pagination.Key{{Column: "item_name"}}, ""},
+		{"descending", "-created_at", []pagination.Key{{Column: "created_at", Desc: true}}, ""},
+		{"several keys", " -score, name ,", []pagination.Key{{Column: "score", Desc: true}, {Column: "item_name"}}, ""},
+		{"unknown field", "color", nil, "10011"},
+		{"not sortable", "kind", nil, "10005"},
+		{"json field", "-extra", nil, "10005"},
+		{"field twice", "name,-name", nil, "10005"},
+		{"too many keys", "name,score,created_at,id", nil, "10005"},
+		{"column name instead of field", "item_name", nil, "10011"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := items.ParseSort(tt.sort)
+			if code := errorCode(t, err); code != tt.code {
+				t.Fatalf("ParseSort error code = %q, want %q (%v)", code, tt.code, err)
+			}
+
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSort = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	var (
+		id        = clause.Column{Table: clause.CurrentTable, Name: "id"}
+		name      = clause.Column{Table: clause.CurrentTable, Name: "item_name"}
+		done      = clause.Column{Table: clause.CurrentTable, Name: "done"}
+		createdAt = clause.Column{Table: clause.CurrentTable, Name: "created_at"}
+		day       = time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+		many      = "1"
+	)
+
+	for i := 0; i < maxInValues; i++ {
+		many += ",1"
+	}
+
+	tests := []struct {
+		name    string
+		filters map[string]map[string]string
+		want    []clause.Expression
+		code    string
+	}{
+		{"none", nil, []clause.Expression{}, ""},
+		{"equal", map[string]map[string]string{"id": {"eq": "3"}}, []clause.Expression{
+			clause.Expr{SQL: "? = ?", Vars: []interface{}{id, int64(3)}},
+		}, ""},
+		{"range in field and operator order", map[string]map[string]string{
+			"id":   {"lte": "9", "gt": "3"},
+			"done": {"eq": "true"},
+		}, []clause.Expression{
+			clause.Expr{SQL: "? = ?", Vars: []interface{}{done, true}},
+			clause.Expr{SQL: "? > ?", Vars: []interface{}{id, int64(3)}},
+			clause.Expr{SQL: "? <= ?", Vars: []interface{}{id, int64(9)}},
+		}, ""},
+		{"in", map[string]map[string]string{"id": {"in": "1, 2"}}, []clause.Expression{
+			clause.IN{Column: id, Values: []interface{}{int64(1), int64(2)}},
+		}, ""},
+		{"like escapes the wildcards", map[string]map[string]string{"name": {"like": `50%_off\`}}, []clause.Expression{
+			clause.Like{Column: name, Value: `%50\%\_off\\%`},
+		}, ""},
+		{"plain date", map[string]map[string]string{"created_at": {"gte": "2023-01-02"}}, []clause.Expression{
+			clause.Expr{SQL: "? >= ?", Vars: []interface{}{createdAt, day}},
+		}, ""},
+		{"unknown field", map[string]map[string]string{"color": {"eq": "red"}}, nil, "10011"},
+		{"not filterable", map[string]map[string]string{"score": {"eq": "1"}}, nil, "10005"},
+		{"json field", map[string]map[string]string{"extra": {"eq": "{}"}}, nil, "10005"},
+		{"unknown operator", map[string]map[string]string{"id": {"between": "1,2"}}, nil, "10005"},
+		{"operator of another type", map[string]map[string]string{"name": {"gt": "a"}}, nil, "10005"},
+		{"like on a number", map[string]map[string]string{"id": {"like": "1"}}, nil, "10005"},
+		{"not equal on a time", map[string]map[string]string{"created_at": {"ne": "2023-01-02"}}, nil, "10005"},
+		{"not a number", map[string]map[string]string{"id": {"eq": "three"}}, nil, "10005"},
+		{"not a boolean", map[string]map[string]string{"done": {"eq": "maybe"}}, nil, "10005"},
+		{"not a time", map[string]map[string]string{"created_at": {"lt": "yesterday"}}, nil, "10005"},
+		{"bad value in a list", map[string]map[string]string{"id": {"in": "1,x"}}, nil, "10005"},
+		{"too many values", map[string]map[string]string{"id": {"in": many}}, nil, "10005"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := items.ParseFilters(tt.filters)
+			if code := errorCode(t, err); code != tt.code {
+				t.Fatalf("ParseFilters error code = %q, want %q (%v)", code, tt.code, err)
+			}
+
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFilters = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package query
+
+import (
+	"strings"
+
+	"myapp/customError"
+)
+
+// Relation is a relation clients may include in a list, PerParent caps the related rows read for every row.
+type Relation struct {
+	Name      string
+	PerParent int
+	// Default relations are included when the request doesn't say.
+	Default bool
+}
+
+// Selection is what a list reads of every row, the columns, none meaning all of them,
+// and the included relations with their cap per row.
+type Selection struct {
+	Fields   []string
+	Columns  []string
+	Includes map[string]int
+}
+
+// Include reports whether the relation is included and how many of its rows to read per parent.
+func (s *Selection) Include(name string) (int, bool) {
+	limit, ok := s.Includes[name]
+
+	return limit, ok
+}
+
+// Sparse reports whether the client picked the fields or relations, only then the rows are trimmed to them.
+func (s *Selection) Sparse() bool {
+	return s.Fields != nil
+}
+
+// Keys returns the json keys of a sparse row, the selected fields followed by the included relations,
+// or nil when the rows are whole.
+func (s *Selection) Keys() []string {
+	if !s.Sparse() {
+		return nil
+	}
+
+	keys := append([]string{}, s.Fields...)
+	for name := range s.Includes {
+		keys = append(keys, name)
+	}
+
+	return keys
+}
+
+// WithColumns adds the columns the list itself needs, for relations or the order, to a partial selection.
+func (s *Selection) WithColumns(columns ...string) []string {
+	if len(s.Columns) == 0 {
+		return nil
+	}
+
+	selected := append([]string{}, s.Columns...)
+	for _, column := range columns {
+		found := false
+		for i := range selected {
+			found = found || selected[i] == column
+		}
+
+		if !found {
+			selected = append(selected, column)
+		}
+	}
+
+	return selected
+}
+
+// ParseSelection reads `fields=id,name` and `include=cards`. Without fields every field is read, without
+// include the default relations are; includeSet tells an empty include, which drops them all, from a missing one.
+func (r *Registry) ParseSelection(fields string, include string, includeSet bool) (*Selection, error) {
+	s := &Selection{Includes: make(map[string]int)}
+
+	if strings.TrimSpace(fields) != "" {
+		s.Fields = []string{"id"}
+		s.Columns = []string{"id"}
+
+		for _, name := range strings.Split(fields, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || name == "id" {
+				continue
+			}
+
+			field, err := r.lookup(name)
+			if err != nil {
+				return nil, err
+			}
+
+			s.Fields = append(s.Fields, field.Name)
+			s.Columns = append(s.Columns, field.Column)
+		}
+	}
+
+	if !includeSet {
+		for i := range r.relations {
+			if r.relations[i].Default {
+				s.Includes[r.relations[i].Name] = r.relations[i].PerParent
+			}
+		}
+
+		return s, nil
+	}
+
+	for _, name := range strings.Split(include, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		relation, ok := r.relationsByName[name]
+		if !ok {
+			return nil, customError.ErrUnknownField(name)
+		}
+
+		s.Includes[relation.Name] = relation.PerParent
+	}
+
+	// the client picked the relations, so the rows only carry what was asked for
+	if s.Fields == nil {
+		s.Fields = make([]string, 0, len(r.fields))
+		for i := range r.fields {
+			s.Fields = append(s.Fields, r.fields[i].Name)
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,17 @@
+package query
+
+const RelationUserCards = "cards"
+
+// Users are the fields and relations of the user list, the email can be selected but not filtered on.
+var Users = NewRegistry(
+	Field{Name: "id", Column: "id", Type: TypeInt, Sortable: true, Filterable: true},
+	Field{Name: "name", Column: "name", Type: TypeString, Sortable: true, Filterable: true},
+	Field{Name: "username", Column: "username", Type: TypeString, Filterable: true},
+	Field{Name: "email", Column: "email", Type: TypeString},
+	Field{Name: "score", Column: "score", Type: TypeInt, Filterable: true},
+	Field{Name: "is_admin", Column: "is_admin", Type: TypeBool, Filterable: true},
+	Field{Name: "created_at", Column: "created_at", Type: TypeTime, Sortable: true, Filterable: true},
+	Field{Name: "updated_at", Column: "updated_at", Type: TypeTime, Sortable: true, Filterable: true},
+).WithRelations(
+	Relation{Name: RelationUserCards, PerParent: 10, Default: true},
+)
@@ -0,0 +1,183 @@
+package base
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/pagination"
+)
+
+// ErrStale is returned by Update when the row moved past the version the update was made from.
+var ErrStale = errors.New("base: the row changed since it was read")
+
+// versioned models embed model.Versioned, their updates check and move the version.
+type versioned interface {
+	VersionRef() *int64
+}
+
+// Base holds the queries every model repository shares, repositories embed it and add their own.
+// Scopes are applied to every read, preloads only to GetByID.
+type Base[T any] struct {
+	getDB    func(ctx context.Context) *gorm.DB
+	scopes   []func(db *gorm.DB) *gorm.DB
+	preloads []string
+}
+
+func New[T any](getDB func(ctx context.Context) *gorm.DB) *Base[T] {
+	return &Base[T]{getDB: getDB}
+}
+
+// WithScopes adds default scopes, such as a condition every read must keep.
+func (b *Base[T]) WithScopes(scopes ...func(db *gorm.DB) *gorm.DB) *Base[T] {
+	b.scopes = append(b.scopes, scopes...)
+
+	return b
+}
+
+// WithPreloads adds the associations GetByID loads with the row.
+func (b *Base[T]) WithPreloads(preloads ...string) *Base[T] {
+	b.preloads = append(b.preloads, preloads...)
+
+	return b
+}
+
+// read returns the client for a read of T with the default scopes applied.
+func (b *Base[T]) read(ctx context.Context) *gorm.DB {
+	return b.getDB(ctx).Model(new(T)).Scopes(b.scopes...)
+}
+
+func (b *Base[T]) Create(ctx context.Context, data *T) error {
+	if v, ok := any(data).(versioned); ok && *v.VersionRef() == 0 {
+		*v.VersionRef() = 1
+	}
+
+	return b.getDB(ctx).Create(data).Error
+}
+
+// Update saves the row alone, associations are left to their own repositories.
+// A versioned row is only saved over the version data was read at, ErrStale tells it changed in between.
+func (b *Base[T]) Update(ctx context.Context, data *T) error {
+	db := b.getDB(ctx).Omit(clause.Associations)
+
+	v, ok := any(data).(versioned)
+	if !ok {
+		return db.Save(data).Error
+	}
+
+	version := v.VersionRef()
+	read := *version
+	*version++
+
+	// selecting the columns keeps Save from creating the row when no version matches
+	result := db.Select("*").Where("version = ?", read).Save(data)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrStale
+	}
+
+	if result.Error != nil {
+		*version = read
+	}
+
+	return result.Error
+}
+
+func (b *Base[T]) GetByID(ctx context.Context, id int64) (*T, error) {
+	var (
+		data T
+		db   = b.read(ctx)
+	)
+
+	for i := range b.preloads {
+		db = db.Preload(b.preloads[i])
+	}
+
+	err := db.Where("id = ?", id).First(&data).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// GetByIDForUpdate locks the row until the surrounding transaction ends.
+func (b *Base[T]) GetByIDForUpdate(ctx context.Context, id int64) (*T, error) {
+	var data T
+
+	err := b.read(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&data).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (b *Base[T]) Delete(ctx context.Context, data *T, unscoped bool) error {
+	db := b.getDB(ctx)
+
+	if unscoped {
+		db = db.Unscoped()
+	}
+
+	return db.Delete(data).Error
+}
+
+// List pages through the rows matching conditions in the order of keyset, the total is -1 when skipCount is set.
+// Only columns are read when some are given, preloads are loaded with the rows.
+func (b *Base[T]) List(
+	ctx context.Context,
+	conditions []clause.Expression,
+	keyset *pagination.Keyset,
+	skipCount bool,
+	columns []string,
+	preloads ...string,
+) ([]T, int64, pagination.Page, error) {
+	var (
+		db          = b.read(ctx)
+		data        = make([]T, 0)
+		total int64 = -1
+		page  pagination.Page
+	)
+
+	if len(conditions) > 0 {
+		db = db.Where(clause.And(conditions...))
+	}
+
+	if !skipCount {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, page, err
+		}
+	}
+
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	for i := range preloads {
+		db = db.Preload(preloads[i])
+	}
+
+	db, err := keyset.Apply(db)
+	if err != nil {
+		return nil, 0, page, err
+	}
+
+	db = db.Find(&data)
+	if db.Error != nil {
+		return nil, 0, page, db.Error
+	}
+
+	data, page, err = pagination.Finish(keyset, db, data)
+	if err != nil {
+		return nil, 0, page, err
+	}
+
+	return data, total, page, nil
+}
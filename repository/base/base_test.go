@@ -0,0 +1,370 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"myapp/model"
+	"myapp/pagination"
+)
+
+// note is a plain model, widget a versioned one.
+type note struct {
+	ID        int64 `gorm:"primaryKey"`
+	Body      string
+	Hidden    bool
+	DeletedAt gorm.DeletedAt
+}
+
+type widget struct {
+	ID        int64 `gorm:"primaryKey"`
+	Name      string
+	DeletedAt gorm.DeletedAt
+	model.Versioned
+}
+
+// databases opens SQLite in memory, and MySQL when TEST_MYSQL_DSN points at a database the tests may write to.
+func databases(t *testing.T) map[string]*gorm.DB {
+	t.Helper()
+
+	dialectors := map[string]gorm.Dialector{
+		"sqlite": sqlite.Open("file::memory:"),
+	}
+
+	if dsn := os.Getenv("TEST_MYSQL_DSN"); dsn != "" {
+		dialectors["mysql"] = mysql.Open(dsn)
+	}
+
+	dbs := make(map[string]*gorm.DB, len(dialectors))
+	for name, dialector := range dialectors {
+		db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		sqlDB, err := db.DB()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		// every connection to :memory: is a database of its own
+		sqlDB.SetMaxOpenConns(1)
+
+		if err = db.Migrator().DropTable(&note{}, &widget{}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if err = db.AutoMigrate(&note{}, &widget{}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		t.Cleanup(func() {
+			_ = db.Migrator().DropTable(&note{}, &widget{})
+			_ = sqlDB.Close()
+		})
+
+		dbs[name] = db
+	}
+
+	return dbs
+}
+
+func forEachDatabase(t *testing.T, test func(t *testing.T, db *gorm.DB)) {
+	for name, db := range databases(t) {
+		db := db
+		t.Run(name, func(t *testing.T) {
+			test(t, db)
+		})
+	}
+}
+
+func getDB(db *gorm.DB) func(ctx context.Context) *gorm.DB {
+	return func(ctx context.Context) *gorm.DB {
+		return db.WithContext(ctx)
+	}
+}
+
+func TestCreateAndGetByID(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		var (
+			ctx   = context.Background()
+			notes = New[note](getDB(db))
+			n     = &note{Body: "first"}
+		)
+
+		if err := notes.Create(ctx, n); err != nil {
+			t.Fatal(err)
+		}
+
+		if n.ID == 0 {
+			t.Fatal("Create left the id empty")
+		}
+
+		got, err := notes.GetByID(ctx, n.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Body != "first" {
+			t.Errorf("GetByID body = %q, want %q", got.Body, "first")
+		}
+
+		if _, err = notes.GetByID(ctx, n.ID+1); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("GetByID of a missing row = %v, want ErrRecordNotFound", err)
+		}
+
+		if _, err = notes.GetByIDForUpdate(ctx, n.ID); err != nil {
+			t.Errorf("GetByIDForUpdate = %v", err)
+		}
+	})
+}
+
+func TestScopes(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		var (
+			ctx     = context.Background()
+			visible = func(db *gorm.DB) *gorm.DB { return db.Where("hidden = ?", false) }
+			notes   = New[note](getDB(db)).WithScopes(visible)
+			hidden  = &note{Body: "hidden", Hidden: true}
+		)
+
+		if err := notes.Create(ctx, hidden); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := notes.GetByID(ctx, hidden.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("GetByID of a scoped out row = %v, want ErrRecordNotFound", err)
+		}
+
+		if _, err := notes.GetByIDForUpdate(ctx, hidden.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("GetByIDForUpdate of a scoped out row = %v, want ErrRecordNotFound", err)
+		}
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		var (
+			ctx   = context.Background()
+			notes = New[note](getDB(db))
+			n     = &note{Body: "before"}
+		)
+
+		if err := notes.Create(ctx, n); err != nil {
+			t.Fatal(err)
+		}
+
+		n.Body = "after"
+		if err := notes.Update(ctx, n); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := notes.GetByID(ctx, n.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Body != "after" {
+			t.Errorf("body after Update = %q, want %q", got.Body, "after")
+		}
+	})
+}
+
+func TestVersionedUpdate(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		var (
+			ctx     = context.Background()
+			widgets = New[widget](getDB(db))
+			w       = &widget{Name: "v1"}
+		)
+
+		if err := widgets.Create(ctx, w); err != nil {
+			t.Fatal(err)
+		}
+
+		if w.Version != 1 {
+			t.Fatalf("version after Create = %d, want 1", w.Version)
+		}
+
+		stale, err := widgets.GetByID(ctx, w.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w.Name = "v2"
+		if err = widgets.Update(ctx, w); err != nil {
+			t.Fatal(err)
+		}
+
+		if w.Version != 2 {
+			t.Errorf("version after Update = %d, want 2", w.Version)
+		}
+
+		// stale was read at version 1, which is gone
+		stale.Name = "lost"
+		if err = widgets.Update(ctx, stale); !errors.Is(err, ErrStale) {
+			t.Fatalf("Update of a stale row = %v, want ErrStale", err)
+		}
+
+		if stale.Version != 1 {
+			t.Errorf("version after a stale Update = %d, want it back at 1", stale.Version)
+		}
+
+		got, err := widgets.GetByID(ctx, w.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Name != "v2" || got.Version != 2 {
+			t.Errorf("row after a stale Update = %q at %d, want %q at 2", got.Name, got.Version, "v2")
+		}
+	})
+}
+
+func TestVersionedUpdateOfDeletedRow(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		var (
+			ctx     = context.Background()
+			widgets = New[widget](getDB(db))
+			w       = &widget{Name: "gone"}
+		)
+
+		if err := widgets.Create(ctx, w); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := widgets.Delete(ctx, &widget{ID: w.ID}, true); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := widgets.Update(ctx, w); !errors.Is(err, ErrStale) {
+			t.Fatalf("Update of a deleted row = %v, want ErrStale", err)
+		}
+
+		var count int64
+		if err := db.Unscoped().Model(&widget{}).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 0 {
+			t.Errorf("Update of a deleted row created it again")
+		}
+	})
+}
+
+func TestDelete(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		var (
+			ctx   = context.Background()
+			notes = New[note](getDB(db))
+			n     = &note{Body: "deleted"}
+		)
+
+		if err := notes.Create(ctx, n); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := notes.Delete(ctx, n, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := notes.GetByID(ctx, n.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("GetByID of a soft deleted row = %v, want ErrRecordNotFound", err)
+		}
+
+		var count int64
+		if err := db.Unscoped().Model(&note{}).Where("id = ?", n.ID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 1 {
+			t.Fatalf("soft delete removed the row")
+		}
+
+		if err := notes.Delete(ctx, n, true); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := db.Unscoped().Model(&note{}).Where("id = ?", n.ID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 0 {
+			t.Errorf("unscoped delete kept the row")
+		}
+	})
+}
+
+func TestList(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		var (
+			ctx     = context.Background()
+			visible = func(db *gorm.DB) *gorm.DB { return db.Where("hidden = ?", false) }
+			notes   = New[note](getDB(db)).WithScopes(visible)
+		)
+
+		for _, body := range []string{"a", "b", "c", "d", "e"} {
+			if err := notes.Create(ctx, &note{Body: body}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := notes.Create(ctx, &note{Body: "hidden", Hidden: true}); err != nil {
+			t.Fatal(err)
+		}
+
+		keys := []pagination.Key{{Column: "body", Desc: true}}
+		conditions := []clause.Expression{clause.Neq{Column: "body", Value: "e"}}
+
+		keyset, err := pagination.NewKeyset(keys, "", 1, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rows, total, page, err := notes.List(ctx, conditions, keyset, false, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if total != 4 {
+			t.Errorf("total = %d, want 4", total)
+		}
+
+		if len(rows) != 2 || rows[0].Body != "d" || rows[1].Body != "c" {
+			t.Fatalf("first page = %v, want d and c", rows)
+		}
+
+		if page.NextCursor == "" || page.PrevCursor != "" {
+			t.Fatalf("cursors of the first page = %+v", page)
+		}
+
+		keyset, err = pagination.NewKeyset(keys, page.NextCursor, 0, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rows, total, page, err = notes.List(ctx, conditions, keyset, true, []string{"id", "body"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if total != -1 {
+			t.Errorf("total with skipCount = %d, want -1", total)
+		}
+
+		if len(rows) != 2 || rows[0].Body != "b" || rows[1].Body != "a" {
+			t.Fatalf("second page = %v, want b and a", rows)
+		}
+
+		if page.NextCursor != "" || page.PrevCursor == "" {
+			t.Errorf("cursors of the last page = %+v", page)
+		}
+	})
+}
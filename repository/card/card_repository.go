@@ -2,17 +2,43 @@ package card
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"myapp/model"
+	"myapp/pagination"
+	"myapp/query"
+	"myapp/repository/base"
 )
 
 type Repository interface {
 	Create(ctx context.Context, data *model.Card) error
 	Update(ctx context.Context, data *model.Card) error
 	GetByID(ctx context.Context, id int64) (*model.Card, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*model.Card, error)
+	LockByID(ctx context.Context, id int64) error
+	IsCardTypeUsed(ctx context.Context, code string) (bool, error)
 	Delete(ctx context.Context, data *model.Card, unscoped bool) error
+	AddCommentCount(ctx context.Context, id int64, delta int64) error
+	GetByName(ctx context.Context, userId int64, nameCard string) (*model.Card, error)
+	FindInBatches(ctx context.Context, conditions interface{}, batchSize int, fn func(cards []model.Card) error) error
+	GetDeletedByID(ctx context.Context, id int64) (*model.Card, error)
+	GetDeletedBefore(ctx context.Context, before time.Time, limit int) ([]model.Card, error)
+	Restore(ctx context.Context, data *model.Card) error
+	Purge(ctx context.Context, data *model.Card) error
 	GetList(
+		ctx context.Context,
+		search string,
+		conditions []clause.Expression,
+		keyset *pagination.Keyset,
+		skipCount bool,
+		inactiveOwnerId int64,
+		selection *query.Selection,
+	) ([]model.Card, int64, pagination.Page, error)
+	GetExpired(ctx context.Context, now time.Time, limit int) ([]model.Card, error)
+	Archive(ctx context.Context, data *model.Card, now time.Time) (bool, error)
+	GetDeletedList(
 		ctx context.Context,
 		search string,
 		page int,
@@ -22,62 +48,359 @@ type Repository interface {
 	) ([]model.Card, int64, error)
 }
 
+// dependentTables reference cards(id) and are emptied before a card is purged.
+var dependentTables = []string{
+	"card_shares",
+	"card_tags",
+	"card_attachments",
+	"card_revisions",
+	"card_comment_mentions",
+	"card_comments",
+	"deck_cards",
+}
+
 func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
-	return &pgRepository{getDB}
+	return &pgRepository{base.New[model.Card](getDB).WithPreloads("Tags"), getDB}
 }
 
+// pgRepository does not filter on the current user in GetByID and GetByIDForUpdate,
+// callers must authorize through the policy package.
 type pgRepository struct {
+	*base.Base[model.Card]
 	getDB func(ctx context.Context) *gorm.DB
 }
 
-func (p *pgRepository) Create(ctx context.Context, data *model.Card) error {
-	return p.getDB(ctx).Create(data).Error
+// AddCommentCount moves the comment counter in place, the other fields of the card are left untouched.
+// It writes through the table since the model keeps comment_count read only.
+func (p *pgRepository) AddCommentCount(ctx context.Context, id int64, delta int64) error {
+	return p.getDB(ctx).
+		Table("cards").
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"comment_count": gorm.Expr("comment_count + ?", delta),
+			"version":       gorm.Expr("version + 1"),
+		}).
+		Error
 }
 
-func (p *pgRepository) Update(ctx context.Context, data *model.Card) error {
-	return p.getDB(ctx).Save(data).Error
+// LockByID locks the card row until the surrounding transaction ends, cards in the trash included.
+func (p *pgRepository) LockByID(ctx context.Context, id int64) error {
+	return p.getDB(ctx).
+		Unscoped().
+		Model(&model.Card{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", id).
+		Take(&model.Card{}).
+		Error
 }
 
-func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.Card, error) {
-	var user model.Card
+// IsCardTypeUsed reports whether any card refers to the card type, cards in the trash included.
+func (p *pgRepository) IsCardTypeUsed(ctx context.Context, code string) (bool, error) {
+	var ids []int64
 
-	err := p.getDB(ctx).Debug().
-		Where("id = ? AND user_id = ?", id, ctx.Value("user_id")).
-		First(&user).
+	err := p.getDB(ctx).
+		Unscoped().
+		Model(&model.Card{}).
+		Where("card_type = ?", code).
+		Limit(1).
+		Pluck("id", &ids).
+		Error
+
+	return len(ids) > 0, err
+}
+
+// GetByName returns the oldest card of the user with that name.
+func (p *pgRepository) GetByName(ctx context.Context, userId int64, nameCard string) (*model.Card, error) {
+	var card model.Card
+
+	err := p.getDB(ctx).
+		Where("user_id = ? AND name_card = ?", userId, nameCard).
+		Order("id").
+		First(&card).
 		Error
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &user, nil
+	return &card, nil
+}
+
+// FindInBatches hands the matching cards to fn batchSize at a time, ordered by id.
+func (p *pgRepository) FindInBatches(
+	ctx context.Context,
+	conditions interface{},
+	batchSize int,
+	fn func(cards []model.Card) error,
+) error {
+	var (
+		db   = p.getDB(ctx).Model(&model.Card{}).Preload("Tags")
+		data = make([]model.Card, 0, batchSize)
+	)
+
+	if conditions != nil {
+		db = db.Where(conditions)
+	}
+
+	return db.FindInBatches(&data, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(data)
+	}).Error
 }
 
-func (p *pgRepository) Delete(ctx context.Context, data *model.Card, unscoped bool) error {
+// GetDeletedByID only finds soft deleted cards, it does not filter on the current user.
+func (p *pgRepository) GetDeletedByID(ctx context.Context, id int64) (*model.Card, error) {
+	var card model.Card
+
+	err := p.getDB(ctx).
+		Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&card).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+func (p *pgRepository) GetDeletedBefore(ctx context.Context, before time.Time, limit int) ([]model.Card, error) {
+	data := make([]model.Card, 0)
+
+	err := p.getDB(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at").
+		Limit(limit).
+		Find(&data).
+		Error
+
+	return data, err
+}
+
+func (p *pgRepository) Restore(ctx context.Context, data *model.Card) error {
+	err := p.getDB(ctx).
+		Unscoped().
+		Model(data).
+		Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1")}).
+		Error
+
+	if err != nil {
+		return err
+	}
+
+	data.DeletedAt = nil
+	data.Version++
+
+	return nil
+}
+
+// Purge removes the card and every row depending on it, it should run inside a transaction.
+func (p *pgRepository) Purge(ctx context.Context, data *model.Card) error {
 	db := p.getDB(ctx)
 
-	if unscoped {
-		db = db.Unscoped()
+	// the sales and transfers are kept as history, the open ones are cancelled since the card is gone
+	err := db.Exec(
+		"UPDATE card_listings SET status = ?, cancelled_at = ? WHERE card_id = ? AND status = ?",
+		model.CardListingStatusCancelled, time.Now(), data.ID, model.CardListingStatusActive,
+	).Error
+	if err != nil {
+		return err
 	}
 
-	return db.Delete(data).Error
+	err = db.Exec(
+		"UPDATE card_transfers SET status = ?, responded_at = ? WHERE card_id = ? AND status = ?",
+		model.CardTransferStatusCancelled, time.Now(), data.ID, model.CardTransferStatusPending,
+	).Error
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{"card_listings", "card_transfers"} {
+		err = db.Exec("UPDATE "+table+" SET card_id = NULL WHERE card_id = ?", data.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range dependentTables {
+		err := db.Exec("DELETE FROM "+dependentTables[i]+" WHERE card_id = ?", data.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return db.Unscoped().Delete(data).Error
+}
+
+// Active matches the cards which are visible at now, the query side of model.Card.IsActive.
+func Active(now time.Time) clause.Expression {
+	return clause.And(
+		clause.Expr{SQL: "cards.archived_at IS NULL"},
+		clause.Expr{SQL: "(cards.active_from IS NULL OR cards.active_from <= ?)", Vars: []interface{}{now}},
+		clause.Expr{SQL: "(cards.expires_at IS NULL OR cards.expires_at > ?)", Vars: []interface{}{now}},
+	)
 }
 
+// GetList leaves out the cards which aren't active yet, expired or archived,
+// except the ones of inactiveOwnerId. An inactiveOwnerId of 0 keeps none of them.
+// Only the columns and relations of selection are read, the total is -1 when skipCount is set.
 func (p *pgRepository) GetList(
 	ctx context.Context,
 	search string,
+	conditions []clause.Expression,
+	keyset *pagination.Keyset,
+	skipCount bool,
+	inactiveOwnerId int64,
+	selection *query.Selection,
+) ([]model.Card, int64, pagination.Page, error) {
+	active := Active(time.Now())
+
+	if inactiveOwnerId != 0 {
+		conditions = append(conditions, clause.Or(active, clause.Eq{Column: "cards.user_id", Value: inactiveOwnerId}))
+	} else {
+		conditions = append(conditions, active)
+	}
+
+	var preloads []string
+	if _, ok := selection.Include(query.RelationCardUser); ok {
+		preloads = append(preloads, "User")
+	}
+
+	data, total, page, err := p.List(
+		ctx,
+		conditions,
+		keyset,
+		skipCount,
+		selection.WithColumns(append(keyset.Columns(), "user_id")...),
+		preloads...,
+	)
+	if err != nil {
+		return nil, 0, page, err
+	}
+
+	if limit, ok := selection.Include(query.RelationCardTags); ok {
+		err = p.includeTags(ctx, data, limit)
+		if err != nil {
+			return nil, 0, page, err
+		}
+	}
+
+	return data, total, page, nil
+}
+
+// includeTags sets the first limit tags of every card, by id, in a single query.
+func (p *pgRepository) includeTags(ctx context.Context, cards []model.Card, limit int) error {
+	if len(cards) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(cards))
+	for i := range cards {
+		ids = append(ids, cards[i].ID)
+	}
+
+	ranked := p.getDB(ctx).
+		Table("card_tags").
+		Select("card_tags.card_id, card_tags.tag_id, ROW_NUMBER() OVER (PARTITION BY card_tags.card_id ORDER BY card_tags.tag_id) AS row_rank").
+		Where("card_tags.card_id IN ?", ids)
+
+	var rows []struct {
+		model.Tag
+		CardId int64
+	}
+
+	err := p.getDB(ctx).
+		Model(&model.Tag{}).
+		Select("tags.*, ranked.card_id").
+		Joins("JOIN (?) AS ranked ON ranked.tag_id = tags.id", ranked).
+		Where("ranked.row_rank <= ?", limit).
+		Order("ranked.card_id, tags.id").
+		Find(&rows).
+		Error
+	if err != nil {
+		return err
+	}
+
+	byCard := make(map[int64][]model.Tag, len(cards))
+	for i := range rows {
+		byCard[rows[i].CardId] = append(byCard[rows[i].CardId], rows[i].Tag)
+	}
+
+	for i := range cards {
+		cards[i].Tags = byCard[cards[i].ID]
+		if cards[i].Tags == nil {
+			cards[i].Tags = make([]model.Tag, 0)
+		}
+	}
+
+	return nil
+}
+
+// GetExpired returns the cards which expired but aren't archived yet.
+func (p *pgRepository) GetExpired(ctx context.Context, now time.Time, limit int) ([]model.Card, error) {
+	data := make([]model.Card, 0)
+
+	err := p.getDB(ctx).
+		Where("archived_at IS NULL AND expires_at <= ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&data).
+		Error
+
+	return data, err
+}
+
+// Archive returns false when the card was archived in the meantime.
+func (p *pgRepository) Archive(ctx context.Context, data *model.Card, now time.Time) (bool, error) {
+	result := p.getDB(ctx).
+		Model(&model.Card{}).
+		Where("id = ? AND archived_at IS NULL", data.ID).
+		Updates(map[string]interface{}{"archived_at": now, "version": gorm.Expr("version + 1")})
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	data.ArchivedAt = &now
+
+	return true, nil
+}
+
+func (p *pgRepository) GetDeletedList(
+	ctx context.Context,
+	search string,
+	page int,
+	limit int,
+	conditions interface{},
+	order []string,
+) ([]model.Card, int64, error) {
+	db := p.getDB(ctx).Unscoped().Where("cards.deleted_at IS NOT NULL")
+
+	return p.getList(db, search, page, limit, conditions, order)
+}
+
+func (p *pgRepository) getList(
+	db *gorm.DB,
+	search string,
 	page int,
 	limit int,
 	conditions interface{},
 	order []string,
 ) ([]model.Card, int64, error) {
 	var (
-		db     = p.getDB(ctx).Model(&model.Card{}).Debug().Preload("User")
 		data   = make([]model.Card, 0)
 		total  int64
 		offset int
 	)
 
+	db = db.Model(&model.Card{}).Debug().Preload("User").Preload("Tags")
+
 	if conditions != nil {
 		db = db.Where(conditions)
 	}
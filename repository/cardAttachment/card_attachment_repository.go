@@ -0,0 +1,76 @@
+package cardAttachment
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.CardAttachment) error
+	GetByID(ctx context.Context, id int64) (*model.CardAttachment, error)
+	GetListByCard(ctx context.Context, cardId int64) ([]model.CardAttachment, error)
+	GetTotalSizeByUser(ctx context.Context, userId int64) (int64, error)
+	Delete(ctx context.Context, data *model.CardAttachment) error
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.CardAttachment) error {
+	return p.getDB(ctx).Create(data).Error
+}
+
+func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.CardAttachment, error) {
+	var attachment model.CardAttachment
+
+	err := p.getDB(ctx).
+		Where("id = ?", id).
+		First(&attachment).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (p *pgRepository) GetListByCard(ctx context.Context, cardId int64) ([]model.CardAttachment, error) {
+	data := make([]model.CardAttachment, 0)
+
+	err := p.getDB(ctx).
+		Where("card_id = ?", cardId).
+		Order("id").
+		Find(&data).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *pgRepository) GetTotalSizeByUser(ctx context.Context, userId int64) (int64, error) {
+	var total int64
+
+	err := p.getDB(ctx).
+		Model(&model.CardAttachment{}).
+		Select("COALESCE(SUM(size), 0)").
+		Where("user_id = ?", userId).
+		Scan(&total).
+		Error
+
+	return total, err
+}
+
+func (p *pgRepository) Delete(ctx context.Context, data *model.CardAttachment) error {
+	return p.getDB(ctx).Delete(data).Error
+}
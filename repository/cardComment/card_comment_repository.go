@@ -0,0 +1,153 @@
+package cardComment
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.CardComment) error
+	Update(ctx context.Context, data *model.CardComment) error
+	GetByID(ctx context.Context, id int64) (*model.CardComment, error)
+	DeleteThread(ctx context.Context, data *model.CardComment) (int64, error)
+	ReplaceMentions(ctx context.Context, data *model.CardComment, userIds []int64) error
+	GetList(
+		ctx context.Context,
+		search string,
+		page int,
+		limit int,
+		conditions interface{},
+		order []string,
+	) ([]model.CardComment, int64, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.CardComment) error {
+	return p.getDB(ctx).Omit(clause.Associations).Create(data).Error
+}
+
+func (p *pgRepository) Update(ctx context.Context, data *model.CardComment) error {
+	return p.getDB(ctx).Omit(clause.Associations).Save(data).Error
+}
+
+func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.CardComment, error) {
+	var comment model.CardComment
+
+	err := p.getDB(ctx).
+		Preload("User").
+		Preload("Mentions").
+		Where("id = ?", id).
+		First(&comment).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// DeleteThread soft deletes the comment together with its replies and returns how many comments went away.
+func (p *pgRepository) DeleteThread(ctx context.Context, data *model.CardComment) (int64, error) {
+	db := p.getDB(ctx).
+		Model(&model.CardComment{}).
+		Where("id = ? OR parent_id = ?", data.ID, data.ID).
+		Update("deleted_at", time.Now())
+
+	return db.RowsAffected, db.Error
+}
+
+// ReplaceMentions swaps the mentions of the comment for userIds.
+func (p *pgRepository) ReplaceMentions(ctx context.Context, data *model.CardComment, userIds []int64) error {
+	db := p.getDB(ctx)
+
+	err := db.Where("comment_id = ?", data.ID).Delete(&model.CardCommentMention{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(userIds) == 0 {
+		return nil
+	}
+
+	mentions := make([]model.CardCommentMention, 0, len(userIds))
+	for i := range userIds {
+		mentions = append(mentions, model.CardCommentMention{
+			CommentId: data.ID,
+			UserId:    userIds[i],
+			CardId:    data.CardId,
+		})
+	}
+
+	return db.Create(&mentions).Error
+}
+
+// GetList fills the reply count of every comment, replies of deleted comments are not counted.
+func (p *pgRepository) GetList(
+	ctx context.Context,
+	search string,
+	page int,
+	limit int,
+	conditions interface{},
+	order []string,
+) ([]model.CardComment, int64, error) {
+	var (
+		db     = p.getDB(ctx).Model(&model.CardComment{})
+		data   = make([]model.CardComment, 0)
+		total  int64
+		offset int
+	)
+
+	if conditions != nil {
+		db = db.Where(conditions)
+	}
+
+	if search != "" {
+		db = db.Where("card_comments.body LIKE ?", "%"+search+"%")
+	}
+
+	if page != 1 {
+		offset = limit * (page - 1)
+	}
+
+	if limit != -1 {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	db = db.
+		Select(
+			"card_comments.*, (SELECT COUNT(*) FROM card_comments AS replies " +
+				"WHERE replies.parent_id = card_comments.id AND replies.deleted_at IS NULL) AS reply_count",
+		).
+		Preload("User").
+		Preload("Mentions")
+
+	for i := range order {
+		db = db.Order(order[i])
+	}
+
+	err := db.Limit(limit).Offset(offset).Find(&data).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit == -1 {
+		total = int64(len(data))
+	}
+
+	return data, total, nil
+}
@@ -0,0 +1,142 @@
+package cardListing
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.CardListing) error
+	Update(ctx context.Context, data *model.CardListing) error
+	GetByID(ctx context.Context, id int64) (*model.CardListing, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*model.CardListing, error)
+	GetActiveByCardID(ctx context.Context, cardId int64) (*model.CardListing, error)
+	GetList(
+		ctx context.Context,
+		search string,
+		page int,
+		limit int,
+		conditions interface{},
+		order []string,
+	) ([]model.CardListing, int64, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.CardListing) error {
+	return p.getDB(ctx).Omit(clause.Associations).Create(data).Error
+}
+
+func (p *pgRepository) Update(ctx context.Context, data *model.CardListing) error {
+	return p.getDB(ctx).Omit(clause.Associations).Save(data).Error
+}
+
+func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.CardListing, error) {
+	var listing model.CardListing
+
+	err := p.getDB(ctx).
+		Preload("Card").
+		Where("id = ?", id).
+		First(&listing).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+// GetByIDForUpdate locks the listing row until the surrounding transaction ends.
+func (p *pgRepository) GetByIDForUpdate(ctx context.Context, id int64) (*model.CardListing, error) {
+	var listing model.CardListing
+
+	err := p.getDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&listing).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+func (p *pgRepository) GetActiveByCardID(ctx context.Context, cardId int64) (*model.CardListing, error) {
+	var listing model.CardListing
+
+	err := p.getDB(ctx).
+		Where("card_id = ? AND status = ?", cardId, model.CardListingStatusActive).
+		First(&listing).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+func (p *pgRepository) GetList(
+	ctx context.Context,
+	search string,
+	page int,
+	limit int,
+	conditions interface{},
+	order []string,
+) ([]model.CardListing, int64, error) {
+	var (
+		db     = p.getDB(ctx).Model(&model.CardListing{}).Preload("Card")
+		data   = make([]model.CardListing, 0)
+		total  int64
+		offset int
+	)
+
+	if conditions != nil {
+		db = db.Where(conditions)
+	}
+
+	if search != "" {
+		db = db.Where(
+			"card_id IN (?)",
+			p.getDB(ctx).Model(&model.Card{}).Select("id").Where("name_card LIKE ?", "%"+search+"%"),
+		)
+	}
+
+	for i := range order {
+		db = db.Order(order[i])
+	}
+
+	if page != 1 {
+		offset = limit * (page - 1)
+	}
+
+	if limit != -1 {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	err := db.Limit(limit).Offset(offset).Find(&data).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit == -1 {
+		total = int64(len(data))
+	}
+
+	return data, total, nil
+}
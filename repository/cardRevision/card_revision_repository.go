@@ -0,0 +1,105 @@
+package cardRevision
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.CardRevision) error
+	GetLatestRevision(ctx context.Context, cardId int64) (int, error)
+	GetByRevision(ctx context.Context, cardId int64, revision int) (*model.CardRevision, error)
+	DeleteOlderThan(ctx context.Context, cardId int64, revision int) error
+	GetList(
+		ctx context.Context,
+		cardId int64,
+		page int,
+		limit int,
+	) ([]model.CardRevision, int64, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.CardRevision) error {
+	return p.getDB(ctx).Create(data).Error
+}
+
+// GetLatestRevision returns 0 when the card has no revision yet.
+func (p *pgRepository) GetLatestRevision(ctx context.Context, cardId int64) (int, error) {
+	var revision int
+
+	err := p.getDB(ctx).
+		Model(&model.CardRevision{}).
+		Select("COALESCE(MAX(revision), 0)").
+		Where("card_id = ?", cardId).
+		Scan(&revision).
+		Error
+
+	return revision, err
+}
+
+func (p *pgRepository) GetByRevision(ctx context.Context, cardId int64, revision int) (*model.CardRevision, error) {
+	var cardRevision model.CardRevision
+
+	err := p.getDB(ctx).
+		Where("card_id = ? AND revision = ?", cardId, revision).
+		First(&cardRevision).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardRevision, nil
+}
+
+func (p *pgRepository) DeleteOlderThan(ctx context.Context, cardId int64, revision int) error {
+	return p.getDB(ctx).
+		Where("card_id = ? AND revision < ?", cardId, revision).
+		Delete(&model.CardRevision{}).
+		Error
+}
+
+func (p *pgRepository) GetList(
+	ctx context.Context,
+	cardId int64,
+	page int,
+	limit int,
+) ([]model.CardRevision, int64, error) {
+	var (
+		db     = p.getDB(ctx).Model(&model.CardRevision{}).Where("card_id = ?", cardId)
+		data   = make([]model.CardRevision, 0)
+		total  int64
+		offset int
+	)
+
+	if page != 1 {
+		offset = limit * (page - 1)
+	}
+
+	if limit != -1 {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	err := db.Order("revision DESC").Limit(limit).Offset(offset).Find(&data).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit == -1 {
+		total = int64(len(data))
+	}
+
+	return data, total, nil
+}
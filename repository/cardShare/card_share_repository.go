@@ -0,0 +1,97 @@
+package cardShare
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.CardShare) error
+	Update(ctx context.Context, data *model.CardShare) error
+	GetByCardAndUser(ctx context.Context, cardId int64, userId int64) (*model.CardShare, error)
+	GetListByCard(ctx context.Context, cardId int64) ([]model.CardShare, error)
+	GetListByUserAndCards(ctx context.Context, userId int64, cardIds []int64) ([]model.CardShare, error)
+	Delete(ctx context.Context, data *model.CardShare) error
+	DeleteByCardID(ctx context.Context, cardId int64) error
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.CardShare) error {
+	return p.getDB(ctx).Omit(clause.Associations).Create(data).Error
+}
+
+func (p *pgRepository) Update(ctx context.Context, data *model.CardShare) error {
+	return p.getDB(ctx).Omit(clause.Associations).Save(data).Error
+}
+
+func (p *pgRepository) GetByCardAndUser(ctx context.Context, cardId int64, userId int64) (*model.CardShare, error) {
+	var share model.CardShare
+
+	err := p.getDB(ctx).
+		Where("card_id = ? AND user_id = ?", cardId, userId).
+		First(&share).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
+
+func (p *pgRepository) GetListByCard(ctx context.Context, cardId int64) ([]model.CardShare, error) {
+	data := make([]model.CardShare, 0)
+
+	err := p.getDB(ctx).
+		Preload("User").
+		Where("card_id = ?", cardId).
+		Order("id").
+		Find(&data).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *pgRepository) GetListByUserAndCards(
+	ctx context.Context,
+	userId int64,
+	cardIds []int64,
+) ([]model.CardShare, error) {
+	data := make([]model.CardShare, 0)
+	if len(cardIds) == 0 {
+		return data, nil
+	}
+
+	err := p.getDB(ctx).
+		Where("user_id = ? AND card_id IN ?", userId, cardIds).
+		Find(&data).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *pgRepository) Delete(ctx context.Context, data *model.CardShare) error {
+	return p.getDB(ctx).Delete(data).Error
+}
+
+func (p *pgRepository) DeleteByCardID(ctx context.Context, cardId int64) error {
+	return p.getDB(ctx).Where("card_id = ?", cardId).Delete(&model.CardShare{}).Error
+}
@@ -0,0 +1,160 @@
+package cardStat
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/model"
+)
+
+type Repository interface {
+	RebuildTypeCounts(ctx context.Context) error
+	GetLastDay(ctx context.Context) (string, error)
+	ForEachCreatedSince(
+		ctx context.Context,
+		since time.Time,
+		fn func(userId int64, cardType string, createdAt time.Time) error,
+	) error
+	UpsertDailyCreations(ctx context.Context, data []model.CardDailyCreation) error
+	GetTypeCounts(ctx context.Context, userId int64) ([]model.CardTypeCount, error)
+	GetDailyCreations(ctx context.Context, userId int64, from string, to string) ([]model.CardDailyCreation, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+// RebuildTypeCounts recounts the live cards per user and card type, it should run inside a transaction.
+func (p *pgRepository) RebuildTypeCounts(ctx context.Context) error {
+	db := p.getDB(ctx)
+
+	err := db.Exec("DELETE FROM card_type_counts").Error
+	if err != nil {
+		return err
+	}
+
+	return db.Exec(
+		"INSERT INTO card_type_counts (user_id, card_type, total) " +
+			"SELECT user_id, card_type, COUNT(*) FROM cards " +
+			"WHERE deleted_at IS NULL AND user_id IS NOT NULL AND card_type IS NOT NULL " +
+			"GROUP BY user_id, card_type",
+	).Error
+}
+
+// GetLastDay returns the latest day of the daily rollup, or an empty string while it is empty.
+func (p *pgRepository) GetLastDay(ctx context.Context) (string, error) {
+	var day string
+
+	err := p.getDB(ctx).
+		Model(&model.CardDailyCreation{}).
+		Select("COALESCE(DATE_FORMAT(MAX(day), '%Y-%m-%d'), '')").
+		Scan(&day).
+		Error
+
+	return day, err
+}
+
+// ForEachCreatedSince streams the cards created since then, deleted ones included, a zero since streams them all.
+func (p *pgRepository) ForEachCreatedSince(
+	ctx context.Context,
+	since time.Time,
+	fn func(userId int64, cardType string, createdAt time.Time) error,
+) error {
+	db := p.getDB(ctx).
+		Unscoped().
+		Model(&model.Card{}).
+		Select("user_id, card_type, created_at").
+		Where("user_id IS NOT NULL AND card_type IS NOT NULL")
+
+	if !since.IsZero() {
+		db = db.Where("created_at >= ?", since)
+	}
+
+	rows, err := db.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			userId    int64
+			cardType  string
+			createdAt time.Time
+		)
+
+		if err = rows.Scan(&userId, &cardType, &createdAt); err != nil {
+			return err
+		}
+
+		if err = fn(userId, cardType, createdAt); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (p *pgRepository) UpsertDailyCreations(ctx context.Context, data []model.CardDailyCreation) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return p.getDB(ctx).
+		Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"created", "updated_at"})}).
+		Create(&data).
+		Error
+}
+
+// GetTypeCounts sums the rollup of every user when userId is 0.
+func (p *pgRepository) GetTypeCounts(ctx context.Context, userId int64) ([]model.CardTypeCount, error) {
+	var (
+		data = make([]model.CardTypeCount, 0)
+		db   = p.getDB(ctx).
+			Model(&model.CardTypeCount{}).
+			Select("card_type, SUM(total) AS total, MAX(updated_at) AS updated_at").
+			Group("card_type").
+			Order("total DESC").
+			Order("card_type")
+	)
+
+	if userId != 0 {
+		db = db.Where("user_id = ?", userId)
+	}
+
+	err := db.Scan(&data).Error
+
+	return data, err
+}
+
+// GetDailyCreations sums the rollup per day between from and to included, every user when userId is 0.
+func (p *pgRepository) GetDailyCreations(
+	ctx context.Context,
+	userId int64,
+	from string,
+	to string,
+) ([]model.CardDailyCreation, error) {
+	var (
+		data = make([]model.CardDailyCreation, 0)
+		db   = p.getDB(ctx).
+			Model(&model.CardDailyCreation{}).
+			Select("DATE_FORMAT(day, '%Y-%m-%d') AS day, SUM(created) AS created").
+			Where("day BETWEEN ? AND ?", from, to).
+			Group("day").
+			Order("day")
+	)
+
+	if userId != 0 {
+		db = db.Where("user_id = ?", userId)
+	}
+
+	err := db.Scan(&data).Error
+
+	return data, err
+}
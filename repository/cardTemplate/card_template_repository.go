@@ -0,0 +1,104 @@
+package cardTemplate
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.CardTemplate) error
+	GetByID(ctx context.Context, id int64) (*model.CardTemplate, error)
+	Delete(ctx context.Context, data *model.CardTemplate) error
+	GetList(
+		ctx context.Context,
+		search string,
+		page int,
+		limit int,
+		conditions interface{},
+		order []string,
+	) ([]model.CardTemplate, int64, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.CardTemplate) error {
+	return p.getDB(ctx).Create(data).Error
+}
+
+// GetByID does not filter on the current user, callers must authorize through the policy package.
+func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.CardTemplate, error) {
+	var template model.CardTemplate
+
+	err := p.getDB(ctx).
+		Where("id = ?", id).
+		First(&template).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (p *pgRepository) Delete(ctx context.Context, data *model.CardTemplate) error {
+	return p.getDB(ctx).Delete(data).Error
+}
+
+func (p *pgRepository) GetList(
+	ctx context.Context,
+	search string,
+	page int,
+	limit int,
+	conditions interface{},
+	order []string,
+) ([]model.CardTemplate, int64, error) {
+	var (
+		db     = p.getDB(ctx).Model(&model.CardTemplate{})
+		data   = make([]model.CardTemplate, 0)
+		total  int64
+		offset int
+	)
+
+	if conditions != nil {
+		db = db.Where(conditions)
+	}
+
+	if search != "" {
+		db = db.Where("name LIKE ?", "%"+search+"%")
+	}
+
+	for i := range order {
+		db = db.Order(order[i])
+	}
+
+	if page != 1 {
+		offset = limit * (page - 1)
+	}
+
+	if limit != -1 {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	err := db.Limit(limit).Offset(offset).Find(&data).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit == -1 {
+		total = int64(len(data))
+	}
+
+	return data, total, nil
+}
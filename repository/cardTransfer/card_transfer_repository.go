@@ -0,0 +1,149 @@
+package cardTransfer
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.CardTransfer) error
+	Update(ctx context.Context, data *model.CardTransfer) error
+	GetByID(ctx context.Context, id int64) (*model.CardTransfer, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*model.CardTransfer, error)
+	GetPendingByCardID(ctx context.Context, cardId int64) (*model.CardTransfer, error)
+	ExpirePending(ctx context.Context, now time.Time) (int64, error)
+	GetList(
+		ctx context.Context,
+		search string,
+		page int,
+		limit int,
+		conditions interface{},
+		order []string,
+	) ([]model.CardTransfer, int64, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.CardTransfer) error {
+	return p.getDB(ctx).Omit(clause.Associations).Create(data).Error
+}
+
+func (p *pgRepository) Update(ctx context.Context, data *model.CardTransfer) error {
+	return p.getDB(ctx).Omit(clause.Associations).Save(data).Error
+}
+
+func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.CardTransfer, error) {
+	var transfer model.CardTransfer
+
+	err := p.getDB(ctx).
+		Preload("Card").
+		Where("id = ?", id).
+		First(&transfer).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+// GetByIDForUpdate locks the transfer row until the surrounding transaction ends.
+func (p *pgRepository) GetByIDForUpdate(ctx context.Context, id int64) (*model.CardTransfer, error) {
+	var transfer model.CardTransfer
+
+	err := p.getDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&transfer).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+func (p *pgRepository) GetPendingByCardID(ctx context.Context, cardId int64) (*model.CardTransfer, error) {
+	var transfer model.CardTransfer
+
+	err := p.getDB(ctx).
+		Where("card_id = ? AND status = ?", cardId, model.CardTransferStatusPending).
+		First(&transfer).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+func (p *pgRepository) ExpirePending(ctx context.Context, now time.Time) (int64, error) {
+	db := p.getDB(ctx).
+		Model(&model.CardTransfer{}).
+		Where("status = ? AND expires_at <= ?", model.CardTransferStatusPending, now).
+		Updates(map[string]interface{}{
+			"status":       model.CardTransferStatusExpired,
+			"responded_at": now,
+		})
+
+	return db.RowsAffected, db.Error
+}
+
+func (p *pgRepository) GetList(
+	ctx context.Context,
+	search string,
+	page int,
+	limit int,
+	conditions interface{},
+	order []string,
+) ([]model.CardTransfer, int64, error) {
+	var (
+		db     = p.getDB(ctx).Model(&model.CardTransfer{}).Preload("Card")
+		data   = make([]model.CardTransfer, 0)
+		total  int64
+		offset int
+	)
+
+	if conditions != nil {
+		db = db.Where(conditions)
+	}
+
+	for i := range order {
+		db = db.Order(order[i])
+	}
+
+	if page != 1 {
+		offset = limit * (page - 1)
+	}
+
+	if limit != -1 {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	err := db.Limit(limit).Offset(offset).Find(&data).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit == -1 {
+		total = int64(len(data))
+	}
+
+	return data, total, nil
+}
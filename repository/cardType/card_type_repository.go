@@ -0,0 +1,137 @@
+package cardType
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.CardType) error
+	Update(ctx context.Context, data *model.CardType) error
+	GetByID(ctx context.Context, id int64) (*model.CardType, error)
+	GetByCode(ctx context.Context, code string, unscoped bool) (*model.CardType, error)
+	Delete(ctx context.Context, data *model.CardType, unscoped bool) error
+	GetList(
+		ctx context.Context,
+		search string,
+		page int,
+		limit int,
+		conditions interface{},
+		order []string,
+	) ([]model.CardType, int64, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.CardType) error {
+	return p.getDB(ctx).Create(data).Error
+}
+
+func (p *pgRepository) Update(ctx context.Context, data *model.CardType) error {
+	return p.getDB(ctx).Save(data).Error
+}
+
+func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.CardType, error) {
+	var cardType model.CardType
+
+	err := p.getDB(ctx).
+		Where("id = ?", id).
+		First(&cardType).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardType, nil
+}
+
+func (p *pgRepository) GetByCode(ctx context.Context, code string, unscoped bool) (*model.CardType, error) {
+	var cardType model.CardType
+
+	db := p.getDB(ctx)
+
+	if unscoped {
+		db = db.Unscoped()
+	}
+
+	err := db.
+		Where("code = ?", code).
+		First(&cardType).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardType, nil
+}
+
+func (p *pgRepository) Delete(ctx context.Context, data *model.CardType, unscoped bool) error {
+	db := p.getDB(ctx)
+
+	if unscoped {
+		db = db.Unscoped()
+	}
+
+	return db.Delete(data).Error
+}
+
+func (p *pgRepository) GetList(
+	ctx context.Context,
+	search string,
+	page int,
+	limit int,
+	conditions interface{},
+	order []string,
+) ([]model.CardType, int64, error) {
+	var (
+		db     = p.getDB(ctx).Model(&model.CardType{})
+		data   = make([]model.CardType, 0)
+		total  int64
+		offset int
+	)
+
+	if conditions != nil {
+		db = db.Where(conditions)
+	}
+
+	if search != "" {
+		like := "%" + search + "%"
+		db = db.Where("code LIKE ? OR name LIKE ?", like, like)
+	}
+
+	for i := range order {
+		db = db.Order(order[i])
+	}
+
+	if page != 1 {
+		offset = limit * (page - 1)
+	}
+
+	if limit != -1 {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	err := db.Limit(limit).Offset(offset).Find(&data).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit == -1 {
+		total = int64(len(data))
+	}
+
+	return data, total, nil
+}
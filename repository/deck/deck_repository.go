@@ -0,0 +1,129 @@
+package deck
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.Deck) error
+	Update(ctx context.Context, data *model.Deck) error
+	GetByID(ctx context.Context, id int64) (*model.Deck, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*model.Deck, error)
+	Delete(ctx context.Context, data *model.Deck) error
+	GetList(
+		ctx context.Context,
+		search string,
+		page int,
+		limit int,
+		conditions interface{},
+		order []string,
+	) ([]model.Deck, int64, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.Deck) error {
+	return p.getDB(ctx).Omit(clause.Associations).Create(data).Error
+}
+
+func (p *pgRepository) Update(ctx context.Context, data *model.Deck) error {
+	return p.getDB(ctx).Omit(clause.Associations).Save(data).Error
+}
+
+// GetByID does not filter on the current user, callers must check the permission themselves.
+func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.Deck, error) {
+	var deck model.Deck
+
+	err := p.getDB(ctx).
+		Where("id = ?", id).
+		First(&deck).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &deck, nil
+}
+
+// GetByIDForUpdate locks the deck row until the surrounding transaction ends, it serializes the changes
+// to the order of the deck.
+func (p *pgRepository) GetByIDForUpdate(ctx context.Context, id int64) (*model.Deck, error) {
+	var deck model.Deck
+
+	err := p.getDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&deck).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &deck, nil
+}
+
+func (p *pgRepository) Delete(ctx context.Context, data *model.Deck) error {
+	return p.getDB(ctx).Delete(data).Error
+}
+
+func (p *pgRepository) GetList(
+	ctx context.Context,
+	search string,
+	page int,
+	limit int,
+	conditions interface{},
+	order []string,
+) ([]model.Deck, int64, error) {
+	var (
+		db     = p.getDB(ctx).Model(&model.Deck{}).Preload("User")
+		data   = make([]model.Deck, 0)
+		total  int64
+		offset int
+	)
+
+	if conditions != nil {
+		db = db.Where(conditions)
+	}
+
+	if search != "" {
+		db = db.Where("name LIKE ?", "%"+search+"%")
+	}
+
+	for i := range order {
+		db = db.Order(order[i])
+	}
+
+	if page != 1 {
+		offset = limit * (page - 1)
+	}
+
+	if limit != -1 {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	err := db.Limit(limit).Offset(offset).Find(&data).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit == -1 {
+		total = int64(len(data))
+	}
+
+	return data, total, nil
+}
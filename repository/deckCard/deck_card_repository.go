@@ -0,0 +1,174 @@
+package deckCard
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.DeckCard) error
+	UpdatePosition(ctx context.Context, data *model.DeckCard, position string) error
+	Get(ctx context.Context, deckId int64, cardId int64) (*model.DeckCard, error)
+	GetNext(ctx context.Context, deckId int64, position string, excludeCardId int64) (*model.DeckCard, error)
+	GetPrevious(ctx context.Context, deckId int64, position string, excludeCardId int64) (*model.DeckCard, error)
+	GetLast(ctx context.Context, deckId int64, excludeCardId int64) (*model.DeckCard, error)
+	GetAllByDeck(ctx context.Context, deckId int64) ([]model.DeckCard, error)
+	Delete(ctx context.Context, data *model.DeckCard) error
+	GetList(ctx context.Context, deckId int64, page int, limit int) ([]model.DeckCard, int64, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.DeckCard) error {
+	return p.getDB(ctx).Omit(clause.Associations).Create(data).Error
+}
+
+func (p *pgRepository) UpdatePosition(ctx context.Context, data *model.DeckCard, position string) error {
+	err := p.getDB(ctx).
+		Model(&model.DeckCard{}).
+		Where("deck_id = ? AND card_id = ?", data.DeckId, data.CardId).
+		Update("position", position).
+		Error
+
+	if err != nil {
+		return err
+	}
+
+	data.Position = position
+
+	return nil
+}
+
+func (p *pgRepository) Get(ctx context.Context, deckId int64, cardId int64) (*model.DeckCard, error) {
+	var deckCard model.DeckCard
+
+	err := p.getDB(ctx).
+		Where("deck_id = ? AND card_id = ?", deckId, cardId).
+		First(&deckCard).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &deckCard, nil
+}
+
+func (p *pgRepository) first(db *gorm.DB) (*model.DeckCard, error) {
+	var deckCard model.DeckCard
+
+	err := db.Limit(1).Find(&deckCard).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if deckCard.DeckId == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &deckCard, nil
+}
+
+// GetNext returns the card right after position, it returns gorm.ErrRecordNotFound at the end of the deck.
+func (p *pgRepository) GetNext(
+	ctx context.Context,
+	deckId int64,
+	position string,
+	excludeCardId int64,
+) (*model.DeckCard, error) {
+	return p.first(p.getDB(ctx).
+		Where("deck_id = ? AND position > ? AND card_id <> ?", deckId, position, excludeCardId).
+		Order("position"))
+}
+
+// GetPrevious returns the card right before position, it returns gorm.ErrRecordNotFound at the start of the deck.
+func (p *pgRepository) GetPrevious(
+	ctx context.Context,
+	deckId int64,
+	position string,
+	excludeCardId int64,
+) (*model.DeckCard, error) {
+	return p.first(p.getDB(ctx).
+		Where("deck_id = ? AND position < ? AND card_id <> ?", deckId, position, excludeCardId).
+		Order("position DESC"))
+}
+
+func (p *pgRepository) GetLast(ctx context.Context, deckId int64, excludeCardId int64) (*model.DeckCard, error) {
+	return p.first(p.getDB(ctx).
+		Where("deck_id = ? AND card_id <> ?", deckId, excludeCardId).
+		Order("position DESC"))
+}
+
+// GetAllByDeck also returns the cards which are in the trash, it is meant for renumbering the deck.
+func (p *pgRepository) GetAllByDeck(ctx context.Context, deckId int64) ([]model.DeckCard, error) {
+	data := make([]model.DeckCard, 0)
+
+	err := p.getDB(ctx).
+		Where("deck_id = ?", deckId).
+		Order("position").
+		Order("card_id").
+		Find(&data).
+		Error
+
+	return data, err
+}
+
+func (p *pgRepository) Delete(ctx context.Context, data *model.DeckCard) error {
+	return p.getDB(ctx).
+		Where("deck_id = ? AND card_id = ?", data.DeckId, data.CardId).
+		Delete(&model.DeckCard{}).
+		Error
+}
+
+// GetList pages through the cards of a deck in their order, cards in the trash are left out.
+func (p *pgRepository) GetList(
+	ctx context.Context,
+	deckId int64,
+	page int,
+	limit int,
+) ([]model.DeckCard, int64, error) {
+	var (
+		db = p.getDB(ctx).
+			Model(&model.DeckCard{}).
+			Joins("JOIN cards ON cards.id = deck_cards.card_id AND cards.deleted_at IS NULL").
+			Preload("Card").
+			Preload("Card.Tags").
+			Where("deck_cards.deck_id = ?", deckId).
+			Order("deck_cards.position").
+			Order("deck_cards.card_id")
+		data   = make([]model.DeckCard, 0)
+		total  int64
+		offset int
+	)
+
+	if page != 1 {
+		offset = limit * (page - 1)
+	}
+
+	if limit != -1 {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	err := db.Limit(limit).Offset(offset).Find(&data).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit == -1 {
+		total = int64(len(data))
+	}
+
+	return data, total, nil
+}
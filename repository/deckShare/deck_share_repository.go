@@ -0,0 +1,69 @@
+package deckShare
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, data *model.DeckShare) error
+	Update(ctx context.Context, data *model.DeckShare) error
+	GetByDeckAndUser(ctx context.Context, deckId int64, userId int64) (*model.DeckShare, error)
+	GetListByDeck(ctx context.Context, deckId int64) ([]model.DeckShare, error)
+	Delete(ctx context.Context, data *model.DeckShare) error
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) Create(ctx context.Context, data *model.DeckShare) error {
+	return p.getDB(ctx).Omit(clause.Associations).Create(data).Error
+}
+
+func (p *pgRepository) Update(ctx context.Context, data *model.DeckShare) error {
+	return p.getDB(ctx).Omit(clause.Associations).Save(data).Error
+}
+
+func (p *pgRepository) GetByDeckAndUser(ctx context.Context, deckId int64, userId int64) (*model.DeckShare, error) {
+	var share model.DeckShare
+
+	err := p.getDB(ctx).
+		Where("deck_id = ? AND user_id = ?", deckId, userId).
+		First(&share).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &share, nil
+}
+
+func (p *pgRepository) GetListByDeck(ctx context.Context, deckId int64) ([]model.DeckShare, error) {
+	data := make([]model.DeckShare, 0)
+
+	err := p.getDB(ctx).
+		Preload("User").
+		Where("deck_id = ?", deckId).
+		Order("id").
+		Find(&data).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *pgRepository) Delete(ctx context.Context, data *model.DeckShare) error {
+	return p.getDB(ctx).Delete(data).Error
+}
@@ -2,21 +2,69 @@ package repository
 
 import (
 	"context"
+	"myapp/config"
+	"myapp/event"
 	"myapp/repository/card"
+	"myapp/repository/cardAttachment"
+	"myapp/repository/cardComment"
+	"myapp/repository/cardListing"
+	"myapp/repository/cardRevision"
+	"myapp/repository/cardShare"
+	"myapp/repository/cardStat"
+	"myapp/repository/cardTemplate"
+	"myapp/repository/cardTransfer"
+	"myapp/repository/cardType"
+	"myapp/repository/deck"
+	"myapp/repository/deckCard"
+	"myapp/repository/deckShare"
 
 	"gorm.io/gorm"
 
+	"myapp/repository/tag"
 	"myapp/repository/user"
+	"myapp/storage"
 )
 
 type Repository struct {
-	User user.Repository
-	Card card.Repository
+	GetClient      func(ctx context.Context) *gorm.DB
+	User           user.Repository
+	Card           card.Repository
+	CardType       cardType.Repository
+	CardTransfer   cardTransfer.Repository
+	CardListing    cardListing.Repository
+	CardShare      cardShare.Repository
+	Tag            tag.Repository
+	CardAttachment cardAttachment.Repository
+	CardRevision   cardRevision.Repository
+	CardComment    cardComment.Repository
+	Deck           deck.Repository
+	DeckCard       deckCard.Repository
+	DeckShare      deckShare.Repository
+	CardStat       cardStat.Repository
+	CardTemplate   cardTemplate.Repository
+	Blob           storage.Blob
+	Events         event.Publisher
 }
 
 func New(getClient func(ctx context.Context) *gorm.DB) *Repository {
 	return &Repository{
-		User: user.NewPG(getClient),
-		Card: card.NewPG(getClient),
+		GetClient:      getClient,
+		User:           user.NewPG(getClient),
+		Card:           card.NewPG(getClient),
+		CardType:       cardType.NewPG(getClient),
+		CardTransfer:   cardTransfer.NewPG(getClient),
+		CardListing:    cardListing.NewPG(getClient),
+		CardShare:      cardShare.NewPG(getClient),
+		Tag:            tag.NewPG(getClient),
+		CardAttachment: cardAttachment.NewPG(getClient),
+		CardRevision:   cardRevision.NewPG(getClient),
+		CardComment:    cardComment.NewPG(getClient),
+		Deck:           deck.NewPG(getClient),
+		DeckCard:       deckCard.NewPG(getClient),
+		DeckShare:      deckShare.NewPG(getClient),
+		CardStat:       cardStat.NewPG(getClient),
+		CardTemplate:   cardTemplate.NewPG(getClient),
+		Blob:           storage.NewLocal(config.GetConfig().Storage.LocalPath),
+		Events:         event.NewLog(),
 	}
 }
@@ -0,0 +1,136 @@
+package tag
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/model"
+)
+
+type Repository interface {
+	GetOrCreate(ctx context.Context, userId int64, name string) (*model.Tag, error)
+	GetByID(ctx context.Context, id int64) (*model.Tag, error)
+	Attach(ctx context.Context, cardId int64, tagIds []int64) error
+	Detach(ctx context.Context, cardId int64, tagId int64) error
+	GetListWithUsage(
+		ctx context.Context,
+		userId int64,
+		search string,
+		page int,
+		limit int,
+	) ([]model.Tag, int64, error)
+}
+
+func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
+	return &pgRepository{getDB}
+}
+
+type pgRepository struct {
+	getDB func(ctx context.Context) *gorm.DB
+}
+
+func (p *pgRepository) GetOrCreate(ctx context.Context, userId int64, name string) (*model.Tag, error) {
+	tag := model.Tag{UserId: userId, Name: name}
+
+	err := p.getDB(ctx).
+		Where("user_id = ? AND name = ?", userId, name).
+		FirstOrCreate(&tag).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.Tag, error) {
+	var tag model.Tag
+
+	err := p.getDB(ctx).
+		Where("id = ?", id).
+		First(&tag).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (p *pgRepository) Attach(ctx context.Context, cardId int64, tagIds []int64) error {
+	if len(tagIds) == 0 {
+		return nil
+	}
+
+	data := make([]model.CardTag, 0, len(tagIds))
+	for i := range tagIds {
+		data = append(data, model.CardTag{CardId: cardId, TagId: tagIds[i]})
+	}
+
+	// tags which are already attached are skipped
+	return p.getDB(ctx).
+		Clauses(clause.Insert{Modifier: "IGNORE"}).
+		Create(&data).
+		Error
+}
+
+func (p *pgRepository) Detach(ctx context.Context, cardId int64, tagId int64) error {
+	return p.getDB(ctx).
+		Where("card_id = ? AND tag_id = ?", cardId, tagId).
+		Delete(&model.CardTag{}).
+		Error
+}
+
+// GetListWithUsage returns the tags of a user with the number of cards, not deleted, carrying each of them.
+func (p *pgRepository) GetListWithUsage(
+	ctx context.Context,
+	userId int64,
+	search string,
+	page int,
+	limit int,
+) ([]model.Tag, int64, error) {
+	var (
+		db     = p.getDB(ctx).Model(&model.Tag{}).Where("tags.user_id = ?", userId)
+		data   = make([]model.Tag, 0)
+		total  int64
+		offset int
+	)
+
+	if search != "" {
+		db = db.Where("tags.name LIKE ?", "%"+search+"%")
+	}
+
+	if page != 1 {
+		offset = limit * (page - 1)
+	}
+
+	if limit != -1 {
+		err := db.Count(&total).Error
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	err := db.
+		Select("tags.*, COUNT(cards.id) AS usage_count").
+		Joins("LEFT JOIN card_tags ON card_tags.tag_id = tags.id").
+		Joins("LEFT JOIN cards ON cards.id = card_tags.card_id AND cards.deleted_at IS NULL").
+		Group("tags.id").
+		Order("tags.name").
+		Limit(limit).
+		Offset(offset).
+		Find(&data).
+		Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if limit == -1 {
+		total = int64(len(data))
+	}
+
+	return data, total, nil
+}
@@ -2,58 +2,43 @@ package user
 
 import (
 	"context"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"myapp/model"
+	"myapp/pagination"
+	"myapp/query"
+	"myapp/repository/base"
+	"myapp/repository/card"
 )
 
 type Repository interface {
 	Create(ctx context.Context, data *model.User) error
 	Update(ctx context.Context, data *model.User) error
 	GetByID(ctx context.Context, id int64) (*model.User, error)
+	GetByIDForUpdate(ctx context.Context, id int64) (*model.User, error)
 	GetByUsername(ctx context.Context, username string) (*model.User, error)
 	Delete(ctx context.Context, data *model.User, unscoped bool) error
 	GetList(
 		ctx context.Context,
 		search string,
-		page int,
-		limit int,
-		conditions interface{},
-		order []string,
-	) ([]model.User, int64, error)
+		conditions []clause.Expression,
+		keyset *pagination.Keyset,
+		skipCount bool,
+		selection *query.Selection,
+	) ([]model.User, int64, pagination.Page, error)
 }
 
 func NewPG(getDB func(ctx context.Context) *gorm.DB) Repository {
-	return &pgRepository{getDB}
+	return &pgRepository{base.New[model.User](getDB), getDB}
 }
 
 type pgRepository struct {
+	*base.Base[model.User]
 	getDB func(ctx context.Context) *gorm.DB
 }
 
-func (p *pgRepository) Create(ctx context.Context, data *model.User) error {
-	return p.getDB(ctx).Create(data).Error
-}
-
-func (p *pgRepository) Update(ctx context.Context, data *model.User) error {
-	return p.getDB(ctx).Debug().Save(data).Error
-}
-
-func (p *pgRepository) GetByID(ctx context.Context, id int64) (*model.User, error) {
-	var user model.User
-
-	err := p.getDB(ctx).
-		Debug().
-		Where("id = ?", id).
-		First(&user).
-		Error
-
-	if err != nil {
-		return nil, err
-	}
-
-	return &user, nil
-}
-
 func (p *pgRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
 
@@ -70,58 +55,70 @@ func (p *pgRepository) GetByUsername(ctx context.Context, username string) (*mod
 	return &user, nil
 }
 
-func (p *pgRepository) Delete(ctx context.Context, data *model.User, unscoped bool) error {
-	db := p.getDB(ctx)
-
-	if unscoped {
-		db = db.Unscoped()
-	}
-
-	return db.Select("Cards").Debug().Delete(data).Error
-}
-
+// GetList reads the columns and relations of selection and returns a total of -1 when skipCount is set.
 func (p *pgRepository) GetList(
 	ctx context.Context,
 	search string,
-	page int,
-	limit int,
-	conditions interface{},
-	order []string,
-) ([]model.User, int64, error) {
-	var (
-		db     = p.getDB(ctx).Debug().Model(&model.User{}).Preload("Cards")
-		data   = make([]model.User, 0)
-		total  int64
-		offset int
-	)
-
-	if conditions != nil {
-		db = db.Where(conditions)
+	conditions []clause.Expression,
+	keyset *pagination.Keyset,
+	skipCount bool,
+	selection *query.Selection,
+) ([]model.User, int64, pagination.Page, error) {
+	data, total, page, err := p.List(ctx, conditions, keyset, skipCount, selection.WithColumns(keyset.Columns()...))
+	if err != nil {
+		return nil, 0, page, err
 	}
 
-	for i := range order {
-		db = db.Order(order[i])
+	if limit, ok := selection.Include(query.RelationUserCards); ok {
+		err = p.includeCards(ctx, data, limit)
+		if err != nil {
+			return nil, 0, page, err
+		}
 	}
 
-	if page != 1 {
-		offset = limit * (page - 1)
+	return data, total, page, nil
+}
+
+// includeCards sets the first limit active cards of every user, by id, in a single query.
+func (p *pgRepository) includeCards(ctx context.Context, users []model.User, limit int) error {
+	if len(users) == 0 {
+		return nil
 	}
 
-	if limit != -1 {
-		err := db.Count(&total).Error
-		if err != nil {
-			return nil, 0, err
-		}
+	ids := make([]int64, 0, len(users))
+	for i := range users {
+		ids = append(ids, users[i].ID)
 	}
 
-	err := db.Limit(limit).Offset(offset).Find(&data).Error
+	ranked := p.getDB(ctx).
+		Model(&model.Card{}).
+		Select("cards.*, ROW_NUMBER() OVER (PARTITION BY cards.user_id ORDER BY cards.id) AS row_rank").
+		Where("cards.user_id IN ?", ids).
+		Where(card.Active(time.Now()))
+
+	cards := make([]model.Card, 0)
+
+	err := p.getDB(ctx).
+		Table("(?) AS cards", ranked).
+		Where("cards.row_rank <= ?", limit).
+		Order("cards.user_id, cards.id").
+		Find(&cards).
+		Error
 	if err != nil {
-		return nil, 0, err
+		return err
+	}
+
+	byUser := make(map[int64][]model.Card, len(users))
+	for i := range cards {
+		byUser[cards[i].UserId] = append(byUser[cards[i].UserId], cards[i])
 	}
 
-	if limit == -1 {
-		total = int64(len(data))
+	for i := range users {
+		users[i].Cards = byUser[users[i].ID]
+		if users[i].Cards == nil {
+			users[i].Cards = make([]model.Card, 0)
+		}
 	}
 
-	return data, total, nil
+	return nil
 }
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func NewLocal(root string) Blob {
+	return &localBlob{root: root}
+}
+
+type localBlob struct {
+	root string
+}
+
+// path keeps every key inside root, whatever the key contains.
+func (l *localBlob) path(key string) string {
+	return filepath.Join(l.root, filepath.Clean("/"+key))
+}
+
+func (l *localBlob) Put(_ context.Context, key string, r io.Reader) (int64, error) {
+	path := l.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(path)
+		return 0, err
+	}
+
+	return size, nil
+}
+
+func (l *localBlob) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(l.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (l *localBlob) Delete(_ context.Context, key string) error {
+	err := os.Remove(l.path(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package teq
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"myapp/customError"
+)
+
+// ETag is the strong entity tag of a row at version.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// IfMatch returns the version the If-Match header expects, 0 for `*` which matches any version.
+func IfMatch(c echo.Context) (int64, error) {
+	header := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if header == "" {
+		return 0, customError.ErrPreconditionRequired()
+	}
+
+	if header == "*" {
+		return 0, nil
+	}
+
+	// a strong comparison, weak tags never match
+	version, err := strconv.ParseInt(strings.Trim(header, `"`), 10, 64)
+	if err != nil || !strings.HasPrefix(header, `"`) || version <= 0 {
+		return 0, customError.ErrRequestInvalidParam("If-Match")
+	}
+
+	return version, nil
+}
+
+// notModified reports whether the If-None-Match header already holds the row at version.
+func notModified(c echo.Context, version int64) bool {
+	for _, tag := range strings.Split(c.Request().Header.Get("If-None-Match"), ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == ETag(version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Versioned answers with data, the row at version, or with 304 when the client already has that version.
+func (response) Versioned(c echo.Context, version int64, data interface{}) error {
+	c.Response().Header().Set("ETag", ETag(version))
+
+	if notModified(c, version) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return Response.Success(c, data)
+}
@@ -3,6 +3,8 @@ package teq
 import (
 	"math"
 	"mime/multipart"
+	"net/http"
+	"strings"
 )
 
 type FileType string
@@ -12,6 +14,9 @@ const (
 	JPG     FileType = "image/jpg"
 	JPEG    FileType = "image/jpeg"
 	PNG     FileType = "image/png"
+	GIF     FileType = "image/gif"
+	WEBP    FileType = "image/webp"
+	PDF     FileType = "application/pdf"
 )
 
 func ValidateFileSize(file *multipart.FileHeader, maxSize int64) bool {
@@ -35,6 +40,52 @@ func IsImage(fileType string) bool {
 	}
 }
 
+// ValidateAttachment accepts images and PDFs. The declared Content-Type must agree with
+// the type sniffed from the content, so a renamed file can't pass as another type.
+func ValidateAttachment(file *multipart.FileHeader) (FileType, bool) {
+	declared := normalizeFileType(file.Header.Get("Content-Type"))
+	if !IsAttachment(string(declared)) {
+		return UNKNOWN, false
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return UNKNOWN, false
+	}
+	defer f.Close()
+
+	buf := make([]byte, 512)
+	n, _ := f.Read(buf)
+
+	detected := normalizeFileType(http.DetectContentType(buf[:n]))
+	if detected != declared {
+		return UNKNOWN, false
+	}
+
+	return detected, true
+}
+
+func IsAttachment(fileType string) bool {
+	switch FileType(fileType) {
+	case UNKNOWN:
+		return false
+	case JPEG, JPG, PNG, GIF, WEBP, PDF:
+		return true
+	default:
+		return false
+	}
+}
+
+// normalizeFileType drops media type parameters and maps aliases onto a single type.
+func normalizeFileType(fileType string) FileType {
+	fileType = strings.ToLower(strings.TrimSpace(strings.Split(fileType, ";")[0]))
+	if FileType(fileType) == JPG {
+		return JPEG
+	}
+
+	return FileType(fileType)
+}
+
 func FileTypeString(fileType string) string {
 	switch FileType(fileType) {
 	case UNKNOWN:
@@ -45,6 +96,12 @@ func FileTypeString(fileType string) string {
 		return ".jpg"
 	case PNG:
 		return ".png"
+	case GIF:
+		return ".gif"
+	case WEBP:
+		return ".webp"
+	case PDF:
+		return ".pdf"
 	default:
 		return ""
 	}
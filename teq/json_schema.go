@@ -0,0 +1,62 @@
+package teq
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const schemaResource = "schema.json"
+
+// CompileJSONSchema parses a JSON Schema document, it fails on documents which aren't a valid schema.
+func CompileJSONSchema(raw []byte) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaResource, bytes.NewReader(raw)); err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile(schemaResource)
+}
+
+// ValidateJSON returns one "location: message" line for every violation of the schema by raw.
+func ValidateJSON(schema *jsonschema.Schema, raw []byte) ([]string, error) {
+	var value interface{}
+
+	// the validator expects numbers as json.Number
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	err := schema.Validate(value)
+	if err == nil {
+		return nil, nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	return schemaViolations(validationErr, nil), nil
+}
+
+func schemaViolations(err *jsonschema.ValidationError, violations []string) []string {
+	if len(err.Causes) == 0 {
+		location := err.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+
+		return append(violations, location+": "+err.Message)
+	}
+
+	for i := range err.Causes {
+		violations = schemaViolations(err.Causes[i], violations)
+	}
+
+	return violations
+}
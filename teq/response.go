@@ -26,9 +26,15 @@ func (response) Error(c echo.Context, err appError.TeqError) error {
 		errMessage = err.Raw.Error()
 	}
 
-	return c.JSON(err.HTTPCode, map[string]interface{}{
+	body := map[string]interface{}{
 		"code":    err.ErrorCode,
 		"message": err.Message,
 		"info":    errMessage,
-	})
+	}
+
+	if len(err.Fields) > 0 {
+		body["errors"] = err.Fields
+	}
+
+	return c.JSON(err.HTTPCode, body)
 }
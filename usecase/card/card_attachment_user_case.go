@@ -0,0 +1,211 @@
+package card
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+	"myapp/config"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/payload"
+	"myapp/policy"
+	"myapp/presenter"
+	"myapp/teq"
+)
+
+// signAttachment signs the attachment id and expiry so downloads don't need the bearer token.
+func signAttachment(id int64, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(config.GetConfig().Attachment.SigningSecret))
+	mac.Write([]byte(fmt.Sprintf("%d:%d", id, expires)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func attachmentURL(id int64) string {
+	expires := time.Now().Add(config.GetConfig().Attachment.URLTTL).Unix()
+
+	return fmt.Sprintf("/api/attachments/%d/download?expires=%d&signature=%s", id, expires, signAttachment(id, expires))
+}
+
+func (u *UseCase) validateUpload(ctx context.Context, req *payload.UploadCardAttachmentRequest) (teq.FileType, error) {
+	cfg := config.GetConfig().Attachment
+
+	if req.File == nil {
+		return teq.UNKNOWN, customError.ErrRequestInvalidParam("file")
+	}
+
+	if !teq.ValidateFileSize(req.File, cfg.MaxFileSizeMB) {
+		return teq.UNKNOWN, customError.ErrFileTooLarge()
+	}
+
+	fileType, ok := teq.ValidateAttachment(req.File)
+	if !ok {
+		return teq.UNKNOWN, customError.ErrUnsupportedFileType()
+	}
+
+	used, err := u.CardAttachmentRepo.GetTotalSizeByUser(ctx, req.UserId)
+	if err != nil {
+		return teq.UNKNOWN, customError.ErrModelGet(err, "CardAttachment")
+	}
+
+	if used+req.File.Size > cfg.UserQuotaMB*1024*1024 {
+		return teq.UNKNOWN, customError.ErrStorageQuotaExceeded()
+	}
+
+	return fileType, nil
+}
+
+func (u *UseCase) UploadAttachment(
+	ctx context.Context,
+	req *payload.UploadCardAttachmentRequest,
+) (*presenter.CardAttachmentResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.ensureNotListed(ctx, myCard.ID); err != nil {
+		return nil, err
+	}
+
+	fileType, err := u.validateUpload(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, customError.ErrInvalidParams(err)
+	}
+	defer file.Close()
+
+	key := fmt.Sprintf("cards/%d/%s%s", myCard.ID, teq.RandomString(32, false), teq.FileTypeString(string(fileType)))
+
+	size, err := u.Blob.Put(ctx, key, file)
+	if err != nil {
+		return nil, customError.ErrCreate(err)
+	}
+
+	myAttachment := &model.CardAttachment{
+		CardId:      myCard.ID,
+		UserId:      req.UserId,
+		FileName:    filepath.Base(req.File.Filename),
+		ContentType: string(fileType),
+		Size:        size,
+		StorageKey:  key,
+	}
+
+	err = u.CardAttachmentRepo.Create(ctx, myAttachment)
+	if err != nil {
+		_ = u.Blob.Delete(ctx, key)
+		return nil, customError.ErrModelCreate(err)
+	}
+
+	myAttachment.DownloadURL = attachmentURL(myAttachment.ID)
+
+	return &presenter.CardAttachmentResponseWrapper{Attachment: myAttachment}, nil
+}
+
+func (u *UseCase) GetAttachments(
+	ctx context.Context,
+	req *payload.CardAttachmentRequest,
+) (*presenter.ListCardAttachmentResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	myAttachments, err := u.CardAttachmentRepo.GetListByCard(ctx, myCard.ID)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardAttachment")
+	}
+
+	for i := range myAttachments {
+		myAttachments[i].DownloadURL = attachmentURL(myAttachments[i].ID)
+	}
+
+	return &presenter.ListCardAttachmentResponseWrapper{Attachments: myAttachments}, nil
+}
+
+func (u *UseCase) getAttachment(ctx context.Context, cardId int64, id int64) (*model.CardAttachment, error) {
+	myAttachment, err := u.CardAttachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "CardAttachment")
+	}
+
+	if myAttachment.CardId != cardId {
+		return nil, customError.ErrModelNotFound()
+	}
+
+	return myAttachment, nil
+}
+
+func (u *UseCase) DeleteAttachment(ctx context.Context, req *payload.CardAttachmentRequest) error {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionUpdate)
+	if err != nil {
+		return err
+	}
+
+	if err = u.ensureNotListed(ctx, myCard.ID); err != nil {
+		return err
+	}
+
+	myAttachment, err := u.getAttachment(ctx, myCard.ID, req.AttachmentId)
+	if err != nil {
+		return err
+	}
+
+	return u.removeAttachment(ctx, myAttachment)
+}
+
+// DownloadAttachment serves signed urls, it doesn't look at the current user.
+func (u *UseCase) DownloadAttachment(
+	ctx context.Context,
+	req *payload.DownloadCardAttachmentRequest,
+) (*presenter.CardAttachmentFileWrapper, error) {
+	signature := signAttachment(req.ID, req.Expires)
+	if !hmac.Equal([]byte(signature), []byte(req.Signature)) || time.Now().Unix() > req.Expires {
+		return nil, customError.ErrNoPermission()
+	}
+
+	myAttachment, err := u.CardAttachmentRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "CardAttachment")
+	}
+
+	content, err := u.Blob.Get(ctx, myAttachment.StorageKey)
+	if err != nil {
+		return nil, customError.ErrNotFound(err)
+	}
+
+	return &presenter.CardAttachmentFileWrapper{Attachment: myAttachment, Content: content}, nil
+}
+
+func (u *UseCase) removeAttachment(ctx context.Context, attachment *model.CardAttachment) error {
+	err := u.CardAttachmentRepo.Delete(ctx, attachment)
+	if err != nil {
+		return customError.ErrModelDelete(err)
+	}
+
+	// the row is gone, a leftover file is only wasted space
+	if err = u.Blob.Delete(ctx, attachment.StorageKey); err != nil {
+		fmt.Println("DELETE ATTACHMENT BLOB: ", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package card
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm/clause"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/teq"
+)
+
+// attributePath restricts filters to plain dotted keys so that they can be used as a MySQL JSON path.
+var attributePath = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// validateAttributes checks the attributes against the schema of the card type, missing attributes are stored as null.
+func validateAttributes(myCardType *model.CardType, attributes model.JSON) (model.JSON, error) {
+	document := attributes
+	if attributes.IsNull() {
+		attributes, document = nil, model.JSON("{}")
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(document, &fields); err != nil || fields == nil {
+		return nil, customError.ErrRequestInvalidParam("attributes")
+	}
+
+	if myCardType.AttributesSchema.IsNull() {
+		return attributes, nil
+	}
+
+	schema, err := teq.CompileJSONSchema(myCardType.AttributesSchema)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardType")
+	}
+
+	violations, err := teq.ValidateJSON(schema, document)
+	if err != nil {
+		return nil, customError.ErrRequestInvalidParam("attributes")
+	}
+
+	if len(violations) > 0 {
+		return nil, customError.ErrInvalidAttributes(violations)
+	}
+
+	return attributes, nil
+}
+
+// attributeConditions compares the attributes as text, so `attr.level=3` also matches the number 3.
+func attributeConditions(filters map[string][]string) ([]clause.Expression, error) {
+	paths := make([]string, 0, len(filters))
+	for path := range filters {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	conditions := make([]clause.Expression, 0, len(paths))
+	for _, path := range paths {
+		if !attributePath.MatchString(path) || len(filters[path]) == 0 {
+			return nil, customError.ErrRequestInvalidParam("attr." + path)
+		}
+
+		conditions = append(conditions, clause.Expr{
+			SQL:  "JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) IN ?",
+			Vars: []interface{}{jsonPath(path), filters[path]},
+		})
+	}
+
+	return conditions, nil
+}
+
+// jsonPath quotes every key of the dotted path, MySQL rejects unquoted keys which start with a digit.
+func jsonPath(path string) string {
+	legs := strings.Split(path, ".")
+	for i := range legs {
+		legs[i] = strconv.Quote(legs[i])
+	}
+
+	return "$." + strings.Join(legs, ".")
+}
@@ -0,0 +1,189 @@
+package card
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"myapp/appError"
+	"myapp/config"
+	"myapp/customError"
+	"myapp/mysql"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+// errBatchAborted stops an all-or-nothing batch so that the transaction is rolled back.
+var errBatchAborted = errors.New("batch aborted")
+
+func (u *UseCase) validateBatch(req *payload.BatchCardRequest) error {
+	req.Mode = strings.ToLower(strings.TrimSpace(req.Mode))
+	if req.Mode == "" {
+		req.Mode = payload.BatchModeAtomic
+	}
+
+	if req.Mode != payload.BatchModeAtomic && req.Mode != payload.BatchModeBestEffort {
+		return customError.ErrRequestInvalidParam("mode")
+	}
+
+	maxSize := config.GetConfig().Card.BatchMaxSize
+	if len(req.Operations) == 0 || (maxSize > 0 && len(req.Operations) > maxSize) {
+		return customError.ErrRequestInvalidParam("operations")
+	}
+
+	return nil
+}
+
+func (u *UseCase) Batch(
+	ctx context.Context,
+	req *payload.BatchCardRequest,
+) (*presenter.BatchCardResponseWrapper, error) {
+	if err := u.validateBatch(req); err != nil {
+		return nil, err
+	}
+
+	resp := &presenter.BatchCardResponseWrapper{Mode: req.Mode}
+
+	if req.Mode == payload.BatchModeBestEffort {
+		resp.Results = u.runBatch(ctx, req, false)
+		resp.Committed = true
+		summarizeBatch(resp)
+
+		return resp, nil
+	}
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err := mysql.TxEnd(ctx, func(ctx context.Context) error {
+		// a retried transaction starts again from the first operation
+		resp.Results = u.runBatch(ctx, req, true)
+		for i := range resp.Results {
+			if resp.Results[i].Status == presenter.BatchStatusError {
+				return errBatchAborted
+			}
+		}
+
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		resp.Committed = true
+	case errors.Is(err, errBatchAborted):
+		for i := range resp.Results {
+			if resp.Results[i].Status == presenter.BatchStatusOK {
+				resp.Results[i].Status = presenter.BatchStatusRolledBack
+				resp.Results[i].Card = nil
+			}
+		}
+	default:
+		return nil, err
+	}
+
+	summarizeBatch(resp)
+
+	return resp, nil
+}
+
+// runBatch applies the operations in order, with stopOnError the remaining ones are skipped after the first failure.
+func (u *UseCase) runBatch(ctx context.Context, req *payload.BatchCardRequest, stopOnError bool) []presenter.BatchCardResult {
+	var (
+		results = make([]presenter.BatchCardResult, len(req.Operations))
+		failed  bool
+	)
+
+	for i, op := range req.Operations {
+		results[i] = presenter.BatchCardResult{
+			Index: i,
+			Op:    strings.ToLower(strings.TrimSpace(op.Op)),
+			ID:    op.ID,
+		}
+
+		if failed && stopOnError {
+			results[i].Status = presenter.BatchStatusSkipped
+			continue
+		}
+
+		resp, err := u.runBatchOperation(ctx, req.UserId, results[i].Op, op)
+		if err != nil {
+			failed = true
+			results[i].Status = presenter.BatchStatusError
+			results[i].Error = batchError(err)
+
+			continue
+		}
+
+		results[i].Status = presenter.BatchStatusOK
+		if resp != nil {
+			results[i].Card = resp.Card
+			results[i].ID = resp.Card.ID
+		}
+	}
+
+	return results
+}
+
+func (u *UseCase) runBatchOperation(
+	ctx context.Context,
+	userId int64,
+	op string,
+	data payload.BatchCardOperation,
+) (*presenter.CardResponseWrapper, error) {
+	switch op {
+	case payload.BatchOpCreate:
+		req := &payload.CreateCardRequest{UserId: userId}
+		if data.NameCard != nil {
+			req.NameCard = *data.NameCard
+		}
+
+		if data.CardType != nil {
+			req.CardType = *data.CardType
+		}
+
+		req.Attributes = data.Attributes
+
+		return u.Create(ctx, req)
+	case payload.BatchOpUpdate:
+		if data.ID == 0 {
+			return nil, customError.ErrRequestInvalidParam("id")
+		}
+
+		return u.Update(ctx, &payload.UpdateCardRequest{
+			ID:         data.ID,
+			NameCard:   data.NameCard,
+			CardType:   data.CardType,
+			Attributes: data.Attributes,
+			UserId:     userId,
+			Version:    data.Version,
+		})
+	case payload.BatchOpDelete:
+		if data.ID == 0 {
+			return nil, customError.ErrRequestInvalidParam("id")
+		}
+
+		return nil, u.Delete(ctx, &payload.DeleteRequest{ID: data.ID})
+	default:
+		return nil, customError.ErrRequestInvalidParam("op")
+	}
+}
+
+func summarizeBatch(resp *presenter.BatchCardResponseWrapper) {
+	resp.Succeeded, resp.Failed = 0, 0
+
+	for i := range resp.Results {
+		switch resp.Results[i].Status {
+		case presenter.BatchStatusOK:
+			resp.Succeeded++
+		case presenter.BatchStatusError:
+			resp.Failed++
+		}
+	}
+}
+
+func batchError(err error) *presenter.BatchError {
+	var teqErr appError.TeqError
+	if errors.As(err, &teqErr) {
+		return &presenter.BatchError{Code: teqErr.ErrorCode, Message: teqErr.Message}
+	}
+
+	return &presenter.BatchError{Message: err.Error()}
+}
@@ -0,0 +1,365 @@
+package card
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/customError"
+	"myapp/event"
+	"myapp/model"
+	"myapp/mysql"
+	"myapp/payload"
+	"myapp/policy"
+	"myapp/presenter"
+)
+
+const (
+	maxCommentLength = 5000
+	// maxCommentMentions bounds the username lookups of a single comment.
+	maxCommentMentions = 20
+)
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.\-]+)`)
+
+// parseMentions returns the distinct usernames mentioned in body, in order of appearance.
+func parseMentions(body string) []string {
+	var (
+		names = make([]string, 0)
+		seen  = make(map[string]bool)
+	)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		// a mention ending a sentence keeps its username
+		name := strings.TrimRight(match[1], ".-")
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		names = append(names, name)
+		if len(names) == maxCommentMentions {
+			break
+		}
+	}
+
+	return names
+}
+
+// resolveMentions looks the mentioned usernames up, unknown ones stay plain text.
+func (u *UseCase) resolveMentions(ctx context.Context, body string, authorId int64) ([]model.User, error) {
+	mentions := make([]model.User, 0)
+
+	for _, name := range parseMentions(body) {
+		myUser, err := u.UserRepo.GetByUsername(ctx, name)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+
+			return nil, customError.ErrModelGet(err, "User")
+		}
+
+		if myUser.ID != authorId {
+			mentions = append(mentions, *myUser)
+		}
+	}
+
+	return mentions, nil
+}
+
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxCommentLength {
+		return "", customError.ErrRequestInvalidParam("body")
+	}
+
+	return body, nil
+}
+
+func (u *UseCase) getCardComment(ctx context.Context, cardId int64, id int64) (*model.CardComment, error) {
+	myComment, err := u.CardCommentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "CardComment")
+	}
+
+	if myComment.CardId != cardId {
+		return nil, customError.ErrModelNotFound()
+	}
+
+	return myComment, nil
+}
+
+// newMentions returns the users of mentions which aren't in before.
+func newMentions(before []model.User, mentions []model.User) []int64 {
+	var (
+		known = make(map[int64]bool, len(before))
+		added = make([]int64, 0)
+	)
+
+	for i := range before {
+		known[before[i].ID] = true
+	}
+
+	for i := range mentions {
+		if !known[mentions[i].ID] {
+			added = append(added, mentions[i].ID)
+		}
+	}
+
+	return added
+}
+
+func (u *UseCase) saveMentions(ctx context.Context, myComment *model.CardComment, mentions []model.User) error {
+	userIds := make([]int64, 0, len(mentions))
+	for i := range mentions {
+		userIds = append(userIds, mentions[i].ID)
+	}
+
+	if err := u.CardCommentRepo.ReplaceMentions(ctx, myComment, userIds); err != nil {
+		return customError.ErrModelUpdate(err)
+	}
+
+	return nil
+}
+
+func (u *UseCase) publishMentions(ctx context.Context, myComment *model.CardComment, userIds []int64) {
+	if len(userIds) == 0 {
+		return
+	}
+
+	err := u.Events.Publish(ctx, event.Event{
+		Name: event.CardCommentMentioned,
+		Payload: map[string]interface{}{
+			"card_id":    myComment.CardId,
+			"comment_id": myComment.ID,
+			"author_id":  myComment.UserId,
+			"user_ids":   userIds,
+		},
+	})
+	if err != nil {
+		// the comment is saved, a lost notification must not fail it
+		fmt.Println("PUBLISH EVENT: ", err)
+	}
+}
+
+// CreateComment is open to everyone who can view the card, a reply to a reply joins the thread of its parent.
+func (u *UseCase) CreateComment(
+	ctx context.Context,
+	req *payload.CreateCardCommentRequest,
+) (*presenter.CardCommentResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body, err = validateCommentBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	myComment := &model.CardComment{
+		CardId: myCard.ID,
+		UserId: req.UserId,
+		Body:   req.Body,
+	}
+
+	if req.ParentId != 0 {
+		parent, err := u.getCardComment(ctx, myCard.ID, req.ParentId)
+		if err != nil {
+			return nil, customError.ErrRequestInvalidParam("parent_id")
+		}
+
+		myComment.ParentId = &parent.ID
+		if parent.ParentId != nil {
+			myComment.ParentId = parent.ParentId
+		}
+	}
+
+	mentions, err := u.resolveMentions(ctx, req.Body, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err = mysql.TxEnd(ctx, func(ctx context.Context) error {
+		myComment.ID = 0
+
+		if err := u.CardCommentRepo.Create(ctx, myComment); err != nil {
+			return customError.ErrModelCreate(err)
+		}
+
+		if err := u.CardRepo.AddCommentCount(ctx, myCard.ID, 1); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		return u.saveMentions(ctx, myComment, mentions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	myComment.Mentions = mentions
+	u.publishMentions(ctx, myComment, newMentions(nil, mentions))
+
+	return &presenter.CardCommentResponseWrapper{Comment: myComment}, nil
+}
+
+// UpdateComment lets the author change the body, the comment keeps count of its edits.
+func (u *UseCase) UpdateComment(
+	ctx context.Context,
+	req *payload.UpdateCardCommentRequest,
+) (*presenter.CardCommentResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	myComment, err := u.getCardComment(ctx, myCard.ID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := policy.PrincipalFromContext(ctx)
+	if err = policy.Can(principal, policy.ActionUpdate, policy.NewCardCommentResource(myComment, myCard.UserId)); err != nil {
+		return nil, err
+	}
+
+	req.Body, err = validateCommentBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Body == myComment.Body {
+		return &presenter.CardCommentResponseWrapper{Comment: myComment}, nil
+	}
+
+	mentions, err := u.resolveMentions(ctx, req.Body, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	myComment.Body = req.Body
+	myComment.EditCount++
+	myComment.EditedAt = &now
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err = mysql.TxEnd(ctx, func(ctx context.Context) error {
+		if err := u.CardCommentRepo.Update(ctx, myComment); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		return u.saveMentions(ctx, myComment, mentions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// only the users mentioned by this edit are notified
+	added := newMentions(myComment.Mentions, mentions)
+	myComment.Mentions = mentions
+	u.publishMentions(ctx, myComment, added)
+
+	return &presenter.CardCommentResponseWrapper{Comment: myComment}, nil
+}
+
+// DeleteComment soft deletes the comment and, for the first comment of a thread, all of its replies.
+func (u *UseCase) DeleteComment(ctx context.Context, req *payload.DeleteCardCommentRequest) error {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionView)
+	if err != nil {
+		return err
+	}
+
+	myComment, err := u.getCardComment(ctx, myCard.ID, req.ID)
+	if err != nil {
+		return err
+	}
+
+	principal := policy.PrincipalFromContext(ctx)
+	if err = policy.Can(principal, policy.ActionDelete, policy.NewCardCommentResource(myComment, myCard.UserId)); err != nil {
+		return err
+	}
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err = mysql.TxEnd(ctx, func(ctx context.Context) error {
+		deleted, err := u.CardCommentRepo.DeleteThread(ctx, myComment)
+		if err != nil {
+			return customError.ErrModelDelete(err)
+		}
+
+		if err = u.CardRepo.AddCommentCount(ctx, myCard.ID, -deleted); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// GetComments pages through the threads of a card, oldest first, or through the replies of one thread.
+func (u *UseCase) GetComments(
+	ctx context.Context,
+	req *payload.GetListCardCommentRequest,
+) (*presenter.ListCardCommentResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Format()
+
+	var (
+		order      = []string{"card_comments.created_at", "card_comments.id"}
+		conditions = []clause.Expression{clause.Eq{Column: "card_comments.card_id", Value: myCard.ID}}
+	)
+
+	if req.OrderBy != "" {
+		order = []string{req.OrderBy}
+	}
+
+	if req.ParentId != 0 {
+		if _, err = u.getCardComment(ctx, myCard.ID, req.ParentId); err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, clause.Eq{Column: "card_comments.parent_id", Value: req.ParentId})
+	} else {
+		conditions = append(conditions, clause.Expr{SQL: "card_comments.parent_id IS NULL"})
+	}
+
+	myComments, total, err := u.CardCommentRepo.GetList(
+		ctx,
+		req.Search,
+		req.Page,
+		req.Limit,
+		clause.And(conditions...),
+		order,
+	)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardComment")
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListCardCommentResponseWrapper{
+		Comments: myComments,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
@@ -0,0 +1,551 @@
+package card
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/config"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/teq"
+)
+
+// exportBatchSize bounds how many cards are held in memory while an export is streamed.
+const exportBatchSize = 200
+
+var exportCSVHeader = []string{"id", "name_card", "card_type", "attributes", "tags", "created_at", "updated_at"}
+
+// cardFormat falls back on the file extension when no format is given.
+func cardFormat(format string, fileName string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileName)), ".")
+	}
+
+	if format != payload.CardFormatCSV && format != payload.CardFormatJSON {
+		return "", customError.ErrRequestInvalidParam("format")
+	}
+
+	return format, nil
+}
+
+func exportRow(myCard *model.Card) presenter.CardExportRow {
+	tags := make([]string, 0, len(myCard.Tags))
+	for i := range myCard.Tags {
+		tags = append(tags, myCard.Tags[i].Name)
+	}
+
+	return presenter.CardExportRow{
+		ID:         myCard.ID,
+		NameCard:   myCard.NameCard,
+		CardType:   myCard.CardType,
+		Attributes: myCard.Attributes,
+		Tags:       tags,
+		CreatedAt:  myCard.CreatedAt,
+		UpdatedAt:  myCard.UpdatedAt,
+	}
+}
+
+func (u *UseCase) Export(
+	ctx context.Context,
+	req *payload.ExportCardRequest,
+) (*presenter.CardExportFileWrapper, error) {
+	format, err := cardFormat(req.Format, "")
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := clause.Eq{Column: "user_id", Value: req.UserId}
+
+	if format == payload.CardFormatCSV {
+		return &presenter.CardExportFileWrapper{
+			FileName:    "cards.csv",
+			ContentType: "text/csv; charset=utf-8",
+			Write: func(w io.Writer) error {
+				return u.exportCSV(ctx, conditions, w)
+			},
+		}, nil
+	}
+
+	return &presenter.CardExportFileWrapper{
+		FileName:    "cards.json",
+		ContentType: "application/json; charset=utf-8",
+		Write: func(w io.Writer) error {
+			return u.exportJSON(ctx, conditions, w)
+		},
+	}, nil
+}
+
+func (u *UseCase) exportCSV(ctx context.Context, conditions interface{}, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportCSVHeader); err != nil {
+		return err
+	}
+
+	err := u.CardRepo.FindInBatches(ctx, conditions, exportBatchSize, func(cards []model.Card) error {
+		for i := range cards {
+			row := exportRow(&cards[i])
+
+			err := writer.Write([]string{
+				strconv.FormatInt(row.ID, 10),
+				row.NameCard,
+				row.CardType,
+				string(row.Attributes),
+				strings.Join(row.Tags, ","),
+				row.CreatedAt.Format(time.RFC3339),
+				row.UpdatedAt.Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func (u *UseCase) exportJSON(ctx context.Context, conditions interface{}, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	first := true
+	err := u.CardRepo.FindInBatches(ctx, conditions, exportBatchSize, func(cards []model.Card) error {
+		for i := range cards {
+			data, err := json.Marshal(exportRow(&cards[i]))
+			if err != nil {
+				return err
+			}
+
+			if !first {
+				if _, err = io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+
+			if _, err = w.Write(data); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]")
+
+	return err
+}
+
+// importer keeps the state shared by the rows of one import.
+type importer struct {
+	req        *payload.ImportCardRequest
+	resp       *presenter.ImportCardResponseWrapper
+	cardTypes  map[string]*model.CardType
+	duplicates map[string]*model.Card
+}
+
+func (u *UseCase) validateImport(req *payload.ImportCardRequest) (string, error) {
+	if req.File == nil {
+		return "", customError.ErrRequestInvalidParam("file")
+	}
+
+	maxSize := config.GetConfig().Card.ImportMaxFileSizeMB
+	if maxSize > 0 && !teq.ValidateFileSize(req.File, maxSize) {
+		return "", customError.ErrFileTooLarge()
+	}
+
+	req.OnDuplicate = strings.ToLower(strings.TrimSpace(req.OnDuplicate))
+	switch req.OnDuplicate {
+	case "":
+		req.OnDuplicate = payload.DuplicateSkip
+	case payload.DuplicateSkip, payload.DuplicateOverwrite, payload.DuplicateCreate:
+	default:
+		return "", customError.ErrRequestInvalidParam("on_duplicate")
+	}
+
+	return cardFormat(req.Format, req.File.Filename)
+}
+
+// Import applies every valid row on its own, invalid rows are reported and don't stop the others.
+// The file is parsed before the first row is applied, an unreadable or too large file changes nothing.
+func (u *UseCase) Import(
+	ctx context.Context,
+	req *payload.ImportCardRequest,
+) (*presenter.ImportCardResponseWrapper, error) {
+	format, err := u.validateImport(req)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, customError.ErrInvalidParams(err)
+	}
+	defer file.Close()
+
+	var lines []importLine
+	if format == payload.CardFormatCSV {
+		lines, err = parseCSV(file)
+	} else {
+		lines, err = parseJSON(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &importer{
+		req:        req,
+		resp:       &presenter.ImportCardResponseWrapper{DryRun: req.DryRun, Results: make([]presenter.ImportCardResult, 0)},
+		cardTypes:  make(map[string]*model.CardType),
+		duplicates: make(map[string]*model.Card),
+	}
+
+	for i := range lines {
+		if lines[i].err != nil {
+			u.reportImportRow(imp, presenter.ImportCardResult{}, lines[i].err)
+			continue
+		}
+
+		u.importRow(ctx, imp, lines[i].row)
+	}
+
+	return imp.resp, nil
+}
+
+// importLine is a row of the file, or the error which makes that single row unreadable.
+type importLine struct {
+	row *payload.ImportCardRow
+	err error
+}
+
+// checkImportSize refuses files with more rows than the config allows.
+func checkImportSize(lines []importLine) error {
+	maxRows := config.GetConfig().Card.ImportMaxRows
+	if maxRows > 0 && len(lines) >= maxRows {
+		return customError.ErrRequestInvalidParam("file")
+	}
+
+	return nil
+}
+
+func parseCSV(r io.Reader) ([]importLine, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, customError.ErrInvalidParams(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i := range header {
+		columns[strings.ToLower(strings.TrimSpace(header[i]))] = i
+	}
+
+	if _, ok := columns["name_card"]; !ok {
+		return nil, customError.ErrRequestInvalidParam("name_card")
+	}
+
+	column := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return record[i]
+	}
+
+	lines := make([]importLine, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		}
+
+		if err = checkImportSize(lines); err != nil {
+			return nil, err
+		}
+
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, customError.ErrInvalidParams(err)
+		}
+
+		if err != nil {
+			lines = append(lines, importLine{err: customError.ErrInvalidParams(err)})
+			continue
+		}
+
+		row := &payload.ImportCardRow{
+			NameCard: column(record, "name_card"),
+			CardType: column(record, "card_type"),
+		}
+
+		if attributes := strings.TrimSpace(column(record, "attributes")); attributes != "" {
+			row.Attributes = json.RawMessage(attributes)
+		}
+
+		if tags := column(record, "tags"); tags != "" {
+			row.Tags = strings.Split(tags, ",")
+		}
+
+		lines = append(lines, importLine{row: row})
+	}
+}
+
+func parseJSON(r io.Reader) ([]importLine, error) {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, customError.ErrInvalidParams(err)
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, customError.ErrRequestInvalidParam("file")
+	}
+
+	lines := make([]importLine, 0)
+	for decoder.More() {
+		if err = checkImportSize(lines); err != nil {
+			return nil, err
+		}
+
+		row := &payload.ImportCardRow{}
+
+		err = decoder.Decode(row)
+		if err != nil {
+			// a value of the wrong type is consumed whole, anything else leaves the stream unreadable
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return nil, customError.ErrInvalidParams(err)
+			}
+
+			lines = append(lines, importLine{err: customError.ErrInvalidParams(err)})
+			continue
+		}
+
+		lines = append(lines, importLine{row: row})
+	}
+
+	if _, err = decoder.Token(); err != nil {
+		return nil, customError.ErrInvalidParams(err)
+	}
+
+	return lines, nil
+}
+
+// reportImportRow numbers the row and counts it in the summary.
+func (u *UseCase) reportImportRow(imp *importer, result presenter.ImportCardResult, err error) {
+	imp.resp.Total++
+	result.Row = imp.resp.Total
+
+	if err != nil {
+		result.Error = batchError(err)
+		imp.resp.Failed++
+		imp.resp.Results = append(imp.resp.Results, result)
+
+		return
+	}
+
+	switch result.Action {
+	case presenter.ImportActionCreate:
+		imp.resp.Created++
+	case presenter.ImportActionUpdate:
+		imp.resp.Updated++
+	case presenter.ImportActionSkip:
+		imp.resp.Skipped++
+	}
+
+	imp.resp.Results = append(imp.resp.Results, result)
+}
+
+func (u *UseCase) validateImportRow(ctx context.Context, imp *importer, row *payload.ImportCardRow) error {
+	row.NameCard = strings.TrimSpace(row.NameCard)
+	if row.NameCard == "" {
+		return customError.ErrRequestInvalidParam("name_card")
+	}
+
+	row.CardType = strings.TrimSpace(row.CardType)
+	if row.CardType == "" {
+		return customError.ErrRequestInvalidParam("card_type")
+	}
+
+	myCardType, ok := imp.cardTypes[row.CardType]
+	if !ok {
+		var err error
+
+		myCardType, err = u.validateCardType(ctx, row.CardType)
+		if err != nil {
+			return err
+		}
+
+		imp.cardTypes[row.CardType] = myCardType
+	}
+
+	attributes, err := validateAttributes(myCardType, model.JSON(row.Attributes))
+	if err != nil {
+		return err
+	}
+
+	row.Attributes = json.RawMessage(attributes)
+
+	row.Tags, err = normalizeTags(row.Tags)
+	if err != nil {
+		return err
+	}
+
+	if len(row.Tags) > maxTagsPerRequest {
+		return customError.ErrRequestInvalidParam("tags")
+	}
+
+	return nil
+}
+
+// findDuplicate looks at the rows already imported before the stored cards, names are compared case-insensitively.
+func (u *UseCase) findDuplicate(
+	ctx context.Context,
+	imp *importer,
+	nameCard string,
+) (*model.Card, bool, error) {
+	key := strings.ToLower(nameCard)
+	if myCard, ok := imp.duplicates[key]; ok {
+		return myCard, true, nil
+	}
+
+	myCard, err := u.CardRepo.GetByName(ctx, imp.req.UserId, nameCard)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, customError.ErrModelGet(err, "Card")
+	}
+
+	imp.duplicates[key] = myCard
+
+	return myCard, true, nil
+}
+
+func (u *UseCase) importRow(ctx context.Context, imp *importer, row *payload.ImportCardRow) {
+	var result presenter.ImportCardResult
+
+	if err := u.validateImportRow(ctx, imp, row); err != nil {
+		u.reportImportRow(imp, result, err)
+		return
+	}
+
+	duplicate, found, err := u.findDuplicate(ctx, imp, row.NameCard)
+	if err != nil {
+		u.reportImportRow(imp, result, err)
+		return
+	}
+
+	result.Action = presenter.ImportActionCreate
+	if found {
+		switch imp.req.OnDuplicate {
+		case payload.DuplicateSkip:
+			result.Action = presenter.ImportActionSkip
+		case payload.DuplicateOverwrite:
+			result.Action = presenter.ImportActionUpdate
+		}
+	}
+
+	// in a dry run the duplicate may be a row of the file which was never created
+	if duplicate != nil && result.Action != presenter.ImportActionCreate {
+		result.CardId = duplicate.ID
+	}
+
+	if result.Action == presenter.ImportActionUpdate && duplicate != nil {
+		if err = u.ensureNotListed(ctx, duplicate.ID); err != nil {
+			u.reportImportRow(imp, result, err)
+			return
+		}
+	}
+
+	if imp.req.DryRun || result.Action == presenter.ImportActionSkip {
+		if !found {
+			imp.duplicates[strings.ToLower(row.NameCard)] = nil
+		}
+
+		u.reportImportRow(imp, result, nil)
+		return
+	}
+
+	myCard, err := u.applyImportRow(ctx, imp, row, result.Action, duplicate)
+	if myCard != nil {
+		result.CardId = myCard.ID
+		// a later row of the same name updates the card from the version this one wrote
+		imp.duplicates[strings.ToLower(row.NameCard)] = myCard
+	}
+
+	u.reportImportRow(imp, result, err)
+}
+
+// applyImportRow merges the tags of the row into the ones the card already has.
+func (u *UseCase) applyImportRow(
+	ctx context.Context,
+	imp *importer,
+	row *payload.ImportCardRow,
+	action string,
+	duplicate *model.Card,
+) (*model.Card, error) {
+	var (
+		resp *presenter.CardResponseWrapper
+		err  error
+	)
+
+	if action == presenter.ImportActionUpdate {
+		resp, err = u.Update(ctx, &payload.UpdateCardRequest{
+			ID:         duplicate.ID,
+			NameCard:   &row.NameCard,
+			CardType:   &row.CardType,
+			Attributes: row.Attributes,
+			UserId:     imp.req.UserId,
+			// the version the duplicate was found at, a card changed meanwhile isn't overwritten
+			Version: duplicate.Version,
+		})
+	} else {
+		resp, err = u.Create(ctx, &payload.CreateCardRequest{
+			NameCard:   row.NameCard,
+			CardType:   row.CardType,
+			Attributes: row.Attributes,
+			UserId:     imp.req.UserId,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(row.Tags) > 0 {
+		_, err = u.AttachTags(ctx, &payload.AttachCardTagsRequest{
+			CardId: resp.Card.ID,
+			Tags:   row.Tags,
+			UserId: imp.req.UserId,
+		})
+	}
+
+	return resp.Card, err
+}
@@ -0,0 +1,202 @@
+package card
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+
+	"gorm.io/gorm"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/payload"
+	"myapp/policy"
+	"myapp/presenter"
+	"myapp/repository/base"
+)
+
+func (u *UseCase) getRevision(ctx context.Context, cardId int64, revision int) (*model.CardRevision, error) {
+	myRevision, err := u.CardRevisionRepo.GetByRevision(ctx, cardId, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "CardRevision")
+	}
+
+	return myRevision, nil
+}
+
+func (u *UseCase) GetRevisions(
+	ctx context.Context,
+	req *payload.GetListCardRevisionRequest,
+) (*presenter.ListCardRevisionResponseWrapper, error) {
+	req.Format()
+
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	myRevisions, total, err := u.CardRevisionRepo.GetList(ctx, myCard.ID, req.Page, req.Limit)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardRevision")
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListCardRevisionResponseWrapper{
+		Revisions: myRevisions,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
+
+// DiffRevisions compares two revisions field by field, To defaults to the latest revision.
+func (u *UseCase) DiffRevisions(
+	ctx context.Context,
+	req *payload.DiffCardRevisionRequest,
+) (*presenter.CardRevisionDiffResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.To == 0 {
+		req.To, err = u.CardRevisionRepo.GetLatestRevision(ctx, myCard.ID)
+		if err != nil {
+			return nil, customError.ErrModelGet(err, "CardRevision")
+		}
+	}
+
+	if req.From <= 0 {
+		return nil, customError.ErrRequestInvalidParam("from")
+	}
+
+	from, err := u.getRevision(ctx, myCard.ID, req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := u.getRevision(ctx, myCard.ID, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := diffSnapshots(from.Snapshot, to.Snapshot)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardRevision")
+	}
+
+	return &presenter.CardRevisionDiffResponseWrapper{
+		From:    from.Revision,
+		To:      to.Revision,
+		Changes: changes,
+	}, nil
+}
+
+func diffSnapshots(from model.JSON, to model.JSON) ([]presenter.CardRevisionChange, error) {
+	var fromFields, toFields map[string]interface{}
+
+	if err := json.Unmarshal(from, &fromFields); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(to, &toFields); err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(fromFields)+len(toFields))
+	for field := range fromFields {
+		fields = append(fields, field)
+	}
+
+	for field := range toFields {
+		if _, ok := fromFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+
+	changes := make([]presenter.CardRevisionChange, 0)
+	for _, field := range fields {
+		if !reflect.DeepEqual(fromFields[field], toFields[field]) {
+			changes = append(changes, presenter.CardRevisionChange{
+				Field: field,
+				From:  fromFields[field],
+				To:    toFields[field],
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// RestoreRevision copies the versioned fields of a revision back onto the card, the owner is never restored.
+func (u *UseCase) RestoreRevision(
+	ctx context.Context,
+	req *payload.RestoreCardRevisionRequest,
+) (*presenter.CardResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.ensureNotListed(ctx, myCard.ID); err != nil {
+		return nil, err
+	}
+
+	myRevision, err := u.getRevision(ctx, myCard.ID, req.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot model.CardSnapshot
+	if err = json.Unmarshal(myRevision.Snapshot, &snapshot); err != nil {
+		return nil, customError.ErrModelGet(err, "CardRevision")
+	}
+
+	var myCardType *model.CardType
+	if snapshot.CardType != myCard.CardType {
+		myCardType, err = u.validateCardType(ctx, snapshot.CardType)
+	} else {
+		myCardType, err = u.getCardType(ctx, snapshot.CardType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the schema may have changed since the revision was recorded
+	attributes, err := validateAttributes(myCardType, snapshot.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	myCard.NameCard = snapshot.NameCard
+	myCard.CardType = snapshot.CardType
+	myCard.Attributes = attributes
+
+	err = u.Revisions.Write(ctx, myCard, model.CardRevisionActionRestore, req.UserId, func(ctx context.Context) error {
+		err := u.CardRepo.Update(ctx, myCard)
+		switch {
+		case errors.Is(err, base.ErrStale):
+			return customError.ErrPreconditionFailed()
+		case err != nil:
+			return customError.ErrModelUpdate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.CardResponseWrapper{Card: myCard}, nil
+}
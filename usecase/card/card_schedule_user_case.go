@@ -0,0 +1,111 @@
+package card
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"myapp/customError"
+	"myapp/event"
+	"myapp/model"
+	"myapp/payload"
+)
+
+// archiveBatchSize bounds how many expired cards a single ArchiveExpired run handles.
+const archiveBatchSize = 100
+
+// validateSchedule only checks a new expiry against the current time, an expiry which passed since is fine.
+func validateSchedule(activeFrom *time.Time, expiresAt *time.Time, expiresChanged bool) error {
+	if expiresAt == nil {
+		return nil
+	}
+
+	if expiresChanged && !expiresAt.After(time.Now()) {
+		return customError.ErrRequestInvalidParam("expires_at")
+	}
+
+	if activeFrom != nil && !expiresAt.After(*activeFrom) {
+		return customError.ErrRequestInvalidParam("expires_at")
+	}
+
+	return nil
+}
+
+func parseScheduleTime(raw json.RawMessage, param string) (*time.Time, error) {
+	if model.JSON(raw).IsNull() {
+		return nil, nil
+	}
+
+	var value time.Time
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, customError.ErrRequestInvalidParam(param)
+	}
+
+	return &value, nil
+}
+
+// updateSchedule applies the new dates, moving the expiry of an archived card into the future brings it back.
+func updateSchedule(myCard *model.Card, req *payload.UpdateCardRequest) error {
+	var err error
+
+	if req.ActiveFrom != nil {
+		if myCard.ActiveFrom, err = parseScheduleTime(req.ActiveFrom, "active_from"); err != nil {
+			return err
+		}
+	}
+
+	if req.ExpiresAt != nil {
+		if myCard.ExpiresAt, err = parseScheduleTime(req.ExpiresAt, "expires_at"); err != nil {
+			return err
+		}
+	}
+
+	if err = validateSchedule(myCard.ActiveFrom, myCard.ExpiresAt, req.ExpiresAt != nil); err != nil {
+		return err
+	}
+
+	if req.ExpiresAt != nil && myCard.ArchivedAt != nil {
+		myCard.ArchivedAt = nil
+	}
+
+	return nil
+}
+
+// ArchiveExpired archives the cards whose expiry passed and publishes a card.archived event for each of them.
+func (u *UseCase) ArchiveExpired(ctx context.Context) error {
+	now := time.Now()
+
+	myCards, err := u.CardRepo.GetExpired(ctx, now, archiveBatchSize)
+	if err != nil {
+		return customError.ErrModelGet(err, "Card")
+	}
+
+	for i := range myCards {
+		archived, err := u.CardRepo.Archive(ctx, &myCards[i], now)
+		if err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		if !archived {
+			continue
+		}
+
+		err = u.Events.Publish(ctx, event.Event{
+			Name: event.CardArchived,
+			Payload: map[string]interface{}{
+				"card_id":     myCards[i].ID,
+				"user_id":     myCards[i].UserId,
+				"expires_at":  myCards[i].ExpiresAt,
+				"archived_at": myCards[i].ArchivedAt,
+			},
+			OccurredAt: now,
+		})
+		if err != nil {
+			// the card stays archived, a lost event must not block the others
+			fmt.Println("PUBLISH EVENT: ", err)
+		}
+	}
+
+	return nil
+}
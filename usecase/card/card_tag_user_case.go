@@ -0,0 +1,148 @@
+package card
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/customError"
+	"myapp/payload"
+	"myapp/policy"
+	"myapp/presenter"
+)
+
+const (
+	maxTagLength      = 50
+	maxTagsPerRequest = 20
+)
+
+// normalizeTags trims, lower-cases and de-duplicates tag names.
+func normalizeTags(names []string) ([]string, error) {
+	var (
+		tags = make([]string, 0, len(names))
+		seen = make(map[string]bool, len(names))
+	)
+
+	for i := range names {
+		name := strings.ToLower(strings.TrimSpace(names[i]))
+		if name == "" {
+			continue
+		}
+
+		if utf8.RuneCountInString(name) > maxTagLength {
+			return nil, customError.ErrRequestInvalidParam("tags")
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			tags = append(tags, name)
+		}
+	}
+
+	return tags, nil
+}
+
+// tagCondition keeps the cards carrying any, or all, of the given tag names.
+func tagCondition(tags []string, mode string) (clause.Expression, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", payload.TagsModeAny:
+		return clause.Expr{
+			SQL: "id IN (SELECT card_tags.card_id FROM card_tags " +
+				"JOIN tags ON tags.id = card_tags.tag_id WHERE tags.name IN ?)",
+			Vars: []interface{}{tags},
+		}, nil
+	case payload.TagsModeAll:
+		return clause.Expr{
+			SQL: "id IN (SELECT card_tags.card_id FROM card_tags " +
+				"JOIN tags ON tags.id = card_tags.tag_id WHERE tags.name IN ? " +
+				"GROUP BY card_tags.card_id HAVING COUNT(DISTINCT tags.name) = ?)",
+			Vars: []interface{}{tags, len(tags)},
+		}, nil
+	default:
+		return nil, customError.ErrRequestInvalidParam("tags_mode")
+	}
+}
+
+func (u *UseCase) AttachTags(
+	ctx context.Context,
+	req *payload.AttachCardTagsRequest,
+) (*presenter.CardResponseWrapper, error) {
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 || len(tags) > maxTagsPerRequest {
+		return nil, customError.ErrRequestInvalidParam("tags")
+	}
+
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.ensureNotListed(ctx, myCard.ID); err != nil {
+		return nil, err
+	}
+
+	// tags live in the namespace of the card owner, also when a collaborator adds them
+	tagIds := make([]int64, 0, len(tags))
+	for i := range tags {
+		myTag, err := u.TagRepo.GetOrCreate(ctx, myCard.UserId, tags[i])
+		if err != nil {
+			return nil, customError.ErrModelCreate(err)
+		}
+
+		tagIds = append(tagIds, myTag.ID)
+	}
+
+	err = u.TagRepo.Attach(ctx, myCard.ID, tagIds)
+	if err != nil {
+		return nil, customError.ErrModelCreate(err)
+	}
+
+	myCard, err = u.CardRepo.GetByID(ctx, myCard.ID)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "Card")
+	}
+
+	return &presenter.CardResponseWrapper{Card: myCard}, nil
+}
+
+func (u *UseCase) DetachTag(
+	ctx context.Context,
+	req *payload.DetachCardTagRequest,
+) (*presenter.CardResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.ensureNotListed(ctx, myCard.ID); err != nil {
+		return nil, err
+	}
+
+	myTag, err := u.TagRepo.GetByID(ctx, req.TagId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "Tag")
+	}
+
+	err = u.TagRepo.Detach(ctx, myCard.ID, myTag.ID)
+	if err != nil {
+		return nil, customError.ErrModelDelete(err)
+	}
+
+	myCard, err = u.CardRepo.GetByID(ctx, myCard.ID)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "Card")
+	}
+
+	return &presenter.CardResponseWrapper{Card: myCard}, nil
+}
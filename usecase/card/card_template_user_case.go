@@ -0,0 +1,255 @@
+package card
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/payload"
+	"myapp/policy"
+	"myapp/presenter"
+)
+
+// Duplicate copies a card the user can see into a new card of the user. The schedule of the
+// original is not copied, its tags only when the user owns it since tags belong to their user.
+func (u *UseCase) Duplicate(
+	ctx context.Context,
+	req *payload.DuplicateCardRequest,
+) (*presenter.CardResponseWrapper, error) {
+	original, err := u.CardAccess.Get(ctx, req.ID, policy.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	myCard := &model.Card{
+		NameCard:   strings.TrimSpace(req.NameCard),
+		CardType:   original.CardType,
+		Attributes: original.Attributes,
+		UserId:     req.UserId,
+	}
+
+	if myCard.NameCard == "" {
+		myCard.NameCard = original.NameCard
+	}
+
+	if original.UserId == req.UserId {
+		myCard.Tags = original.Tags
+	}
+
+	myCardType, err := u.validateCardType(ctx, myCard.CardType)
+	if err != nil {
+		return nil, err
+	}
+
+	myCard.Attributes, err = validateAttributes(myCardType, myCard.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.Revisions.Write(ctx, myCard, model.CardRevisionActionCreate, req.UserId, func(ctx context.Context) error {
+		// a retried transaction creates the card again
+		myCard.ID = 0
+
+		if err := u.CardRepo.Create(ctx, myCard); err != nil {
+			return customError.ErrModelCreate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.CardResponseWrapper{Card: myCard}, nil
+}
+
+func (u *UseCase) getAuthorizedTemplate(
+	ctx context.Context,
+	id int64,
+	action policy.Action,
+) (*model.CardTemplate, error) {
+	myTemplate, err := u.CardTemplateRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "CardTemplate")
+	}
+
+	err = policy.Can(policy.PrincipalFromContext(ctx), action, policy.NewCardTemplateResource(myTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	return myTemplate, nil
+}
+
+// applyTemplate fills the empty fields of the request from the template,
+// the attributes of the request are merged over the ones of the template.
+func (u *UseCase) applyTemplate(ctx context.Context, req *payload.CreateCardRequest) error {
+	myTemplate, err := u.getAuthorizedTemplate(ctx, req.TemplateId, policy.ActionView)
+	if err != nil {
+		// an unknown template is a bad parameter of the card, not a missing card
+		if appErr, ok := err.(appError.TeqError); ok && appErr.ErrorCode == customError.ErrModelNotFound().ErrorCode {
+			return customError.ErrRequestInvalidParam("template_id")
+		}
+
+		return err
+	}
+
+	if strings.TrimSpace(req.NameCard) == "" {
+		req.NameCard = myTemplate.NameCard
+	}
+
+	if strings.TrimSpace(req.CardType) == "" {
+		req.CardType = myTemplate.CardType
+	}
+
+	req.Attributes, err = mergeAttributes(json.RawMessage(myTemplate.Attributes), req.Attributes)
+	if err != nil {
+		return customError.ErrRequestInvalidParam("attributes")
+	}
+
+	return nil
+}
+
+// mergeAttributes merges the top level keys of override into base, anything but two objects is replaced as a whole.
+func mergeAttributes(base json.RawMessage, override json.RawMessage) (json.RawMessage, error) {
+	if model.JSON(override).IsNull() {
+		return base, nil
+	}
+
+	if model.JSON(base).IsNull() {
+		return override, nil
+	}
+
+	var baseFields, overrideFields map[string]json.RawMessage
+	if json.Unmarshal(base, &baseFields) != nil || json.Unmarshal(override, &overrideFields) != nil {
+		return override, nil
+	}
+
+	for key, value := range overrideFields {
+		baseFields[key] = value
+	}
+
+	return json.Marshal(baseFields)
+}
+
+func (u *UseCase) CreateTemplate(
+	ctx context.Context,
+	req *payload.CreateCardTemplateRequest,
+) (*presenter.CardTemplateResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		req.Name = myCard.NameCard
+	}
+
+	myTemplate := &model.CardTemplate{
+		UserId:     req.UserId,
+		Name:       req.Name,
+		NameCard:   myCard.NameCard,
+		CardType:   myCard.CardType,
+		Attributes: myCard.Attributes,
+		IsGlobal:   req.Global,
+	}
+
+	err = policy.Can(policy.PrincipalFromContext(ctx), policy.ActionCreate, policy.NewCardTemplateResource(myTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.CardTemplateRepo.Create(ctx, myTemplate)
+	if err != nil {
+		return nil, customError.ErrModelCreate(err)
+	}
+
+	return &presenter.CardTemplateResponseWrapper{Template: myTemplate}, nil
+}
+
+// GetTemplates lists the personal templates of the user next to the global ones.
+func (u *UseCase) GetTemplates(
+	ctx context.Context,
+	req *payload.GetListCardTemplateRequest,
+) (*presenter.ListCardTemplateResponseWrapper, error) {
+	req.Format()
+
+	var (
+		order    = []string{"name", "id"}
+		personal = clause.And(
+			clause.Eq{Column: "user_id", Value: req.UserId},
+			clause.Eq{Column: "is_global", Value: false},
+		)
+		global     = clause.Eq{Column: "is_global", Value: true}
+		conditions clause.Expression
+	)
+
+	if req.OrderBy != "" {
+		order = []string{req.OrderBy}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(req.Scope)) {
+	case "", payload.TemplateScopeAll:
+		conditions = clause.Or(personal, global)
+	case payload.TemplateScopePersonal:
+		conditions = personal
+	case payload.TemplateScopeGlobal:
+		conditions = global
+	default:
+		return nil, customError.ErrRequestInvalidParam("scope")
+	}
+
+	myTemplates, total, err := u.CardTemplateRepo.GetList(ctx, req.Search, req.Page, req.Limit, conditions, order)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardTemplate")
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListCardTemplateResponseWrapper{
+		Templates: myTemplates,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
+
+func (u *UseCase) GetTemplate(
+	ctx context.Context,
+	req *payload.GetByIDRequest,
+) (*presenter.CardTemplateResponseWrapper, error) {
+	myTemplate, err := u.getAuthorizedTemplate(ctx, req.ID, policy.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.CardTemplateResponseWrapper{Template: myTemplate}, nil
+}
+
+func (u *UseCase) DeleteTemplate(ctx context.Context, req *payload.DeleteRequest) error {
+	myTemplate, err := u.getAuthorizedTemplate(ctx, req.ID, policy.ActionDelete)
+	if err != nil {
+		return err
+	}
+
+	err = u.CardTemplateRepo.Delete(ctx, myTemplate)
+	if err != nil {
+		return customError.ErrModelDelete(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package card
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/config"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/payload"
+	"myapp/policy"
+	"myapp/presenter"
+)
+
+// purgeBatchSize bounds how many expired cards a single PurgeTrash run removes.
+const purgeBatchSize = 100
+
+func (u *UseCase) GetTrash(
+	ctx context.Context,
+	req *payload.GetListCardTrashRequest,
+) (*presenter.ListCardResponseWrapper, error) {
+	req.Format()
+
+	order := []string{"deleted_at DESC"}
+	if req.OrderBy != "" {
+		order = []string{req.OrderBy}
+	}
+
+	myCards, total, err := u.CardRepo.GetDeletedList(
+		ctx,
+		req.Search,
+		req.Page,
+		req.Limit,
+		clause.Eq{Column: "user_id", Value: req.UserId},
+		order,
+	)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "Card")
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListCardResponseWrapper{
+		Cards: myCards,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
+
+// getTrashedCard only returns cards in the trash of the user.
+func (u *UseCase) getTrashedCard(ctx context.Context, id int64) (*model.Card, error) {
+	myCard, err := u.CardRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "Card")
+	}
+
+	// shares don't reach into the trash, only the owner may restore or purge
+	principal := policy.PrincipalFromContext(ctx)
+	if err = policy.Can(principal, policy.ActionRestore, policy.NewCardResource(myCard, "")); err != nil {
+		return nil, err
+	}
+
+	return myCard, nil
+}
+
+func (u *UseCase) Restore(
+	ctx context.Context,
+	req *payload.RestoreCardRequest,
+) (*presenter.CardResponseWrapper, error) {
+	myCard, err := u.getTrashedCard(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.Revisions.Write(ctx, myCard, model.CardRevisionActionUndelete, req.UserId, func(ctx context.Context) error {
+		if err := u.CardRepo.Restore(ctx, myCard); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(ctx, &payload.GetByIDRequest{ID: myCard.ID})
+}
+
+// deletePermanently purges a card of the user, whether it is still live or already in the trash.
+func (u *UseCase) deletePermanently(ctx context.Context, id int64) error {
+	myCard, err := u.CardRepo.GetDeletedByID(ctx, id)
+	switch {
+	case err == nil:
+		principal := policy.PrincipalFromContext(ctx)
+		if err = policy.Can(principal, policy.ActionDelete, policy.NewCardResource(myCard, "")); err != nil {
+			return err
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		myCard, err = u.CardAccess.Get(ctx, id, policy.ActionDelete)
+		if err != nil {
+			return err
+		}
+
+		if err = u.ensureNotListed(ctx, myCard.ID); err != nil {
+			return err
+		}
+	default:
+		return customError.ErrModelGet(err, "Card")
+	}
+
+	return u.purge(ctx, myCard)
+}
+
+// purge removes the card with all of its dependent rows, the attachment files go once the rows are gone.
+func (u *UseCase) purge(ctx context.Context, myCard *model.Card) error {
+	myAttachments, err := u.CardAttachmentRepo.GetListByCard(ctx, myCard.ID)
+	if err != nil {
+		return customError.ErrModelGet(err, "CardAttachment")
+	}
+
+	err = u.inTx(ctx, func(ctx context.Context) error {
+		if err := u.CardRepo.Purge(ctx, myCard); err != nil {
+			return customError.ErrModelDelete(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range myAttachments {
+		if err = u.Blob.Delete(ctx, myAttachments[i].StorageKey); err != nil {
+			fmt.Println("DELETE ATTACHMENT BLOB: ", err)
+		}
+	}
+
+	return nil
+}
+
+// PurgeTrash permanently removes the cards which stayed in the trash longer than the retention.
+func (u *UseCase) PurgeTrash(ctx context.Context) error {
+	before := time.Now().Add(-config.GetConfig().Card.TrashRetention)
+
+	myCards, err := u.CardRepo.GetDeletedBefore(ctx, before, purgeBatchSize)
+	if err != nil {
+		return customError.ErrModelGet(err, "Card")
+	}
+
+	for i := range myCards {
+		if err = u.purge(ctx, &myCards[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
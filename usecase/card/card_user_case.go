@@ -2,67 +2,236 @@ package card
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"myapp/customError"
+	"myapp/event"
+	"myapp/pagination"
 	"myapp/payload"
+	"myapp/policy"
 	"myapp/presenter"
+	"myapp/query"
 	"myapp/repository"
+	"myapp/repository/base"
 	"myapp/repository/card"
+	"myapp/repository/cardAttachment"
+	"myapp/repository/cardComment"
+	"myapp/repository/cardListing"
+	"myapp/repository/cardRevision"
+	"myapp/repository/cardShare"
+	"myapp/repository/cardTemplate"
+	"myapp/repository/cardType"
+	"myapp/repository/tag"
 	"myapp/repository/user"
+	"myapp/storage"
+	"myapp/usecase/cardAccess"
+	"myapp/usecase/revision"
+	"myapp/validation"
 	"strings"
 
 	"myapp/model"
+	"myapp/mysql"
 )
 
 type CardUseCase interface {
 	Create(ctx context.Context, req *payload.CreateCardRequest) (*presenter.CardResponseWrapper, error)
 	Update(ctx context.Context, req *payload.UpdateCardRequest) (*presenter.CardResponseWrapper, error)
 	GetByID(ctx context.Context, req *payload.GetByIDRequest) (*presenter.CardResponseWrapper, error)
-	GetList(ctx context.Context, req *payload.GetListRequest) (*presenter.ListCardResponseWrapper, error)
+	GetList(ctx context.Context, req *payload.GetListCardRequest) (*presenter.ListCardResponseWrapper, error)
 	Delete(ctx context.Context, req *payload.DeleteRequest) error
+	Batch(ctx context.Context, req *payload.BatchCardRequest) (*presenter.BatchCardResponseWrapper, error)
+	AttachTags(ctx context.Context, req *payload.AttachCardTagsRequest) (*presenter.CardResponseWrapper, error)
+	DetachTag(ctx context.Context, req *payload.DetachCardTagRequest) (*presenter.CardResponseWrapper, error)
+	UploadAttachment(
+		ctx context.Context,
+		req *payload.UploadCardAttachmentRequest,
+	) (*presenter.CardAttachmentResponseWrapper, error)
+	GetAttachments(
+		ctx context.Context,
+		req *payload.CardAttachmentRequest,
+	) (*presenter.ListCardAttachmentResponseWrapper, error)
+	DeleteAttachment(ctx context.Context, req *payload.CardAttachmentRequest) error
+	DownloadAttachment(
+		ctx context.Context,
+		req *payload.DownloadCardAttachmentRequest,
+	) (*presenter.CardAttachmentFileWrapper, error)
+	GetRevisions(
+		ctx context.Context,
+		req *payload.GetListCardRevisionRequest,
+	) (*presenter.ListCardRevisionResponseWrapper, error)
+	DiffRevisions(
+		ctx context.Context,
+		req *payload.DiffCardRevisionRequest,
+	) (*presenter.CardRevisionDiffResponseWrapper, error)
+	RestoreRevision(
+		ctx context.Context,
+		req *payload.RestoreCardRevisionRequest,
+	) (*presenter.CardResponseWrapper, error)
+	GetTrash(ctx context.Context, req *payload.GetListCardTrashRequest) (*presenter.ListCardResponseWrapper, error)
+	Restore(ctx context.Context, req *payload.RestoreCardRequest) (*presenter.CardResponseWrapper, error)
+	PurgeTrash(ctx context.Context) error
+	ArchiveExpired(ctx context.Context) error
+	Export(ctx context.Context, req *payload.ExportCardRequest) (*presenter.CardExportFileWrapper, error)
+	Import(ctx context.Context, req *payload.ImportCardRequest) (*presenter.ImportCardResponseWrapper, error)
+	Duplicate(ctx context.Context, req *payload.DuplicateCardRequest) (*presenter.CardResponseWrapper, error)
+	CreateTemplate(
+		ctx context.Context,
+		req *payload.CreateCardTemplateRequest,
+	) (*presenter.CardTemplateResponseWrapper, error)
+	GetTemplates(
+		ctx context.Context,
+		req *payload.GetListCardTemplateRequest,
+	) (*presenter.ListCardTemplateResponseWrapper, error)
+	GetTemplate(ctx context.Context, req *payload.GetByIDRequest) (*presenter.CardTemplateResponseWrapper, error)
+	DeleteTemplate(ctx context.Context, req *payload.DeleteRequest) error
+	CreateComment(
+		ctx context.Context,
+		req *payload.CreateCardCommentRequest,
+	) (*presenter.CardCommentResponseWrapper, error)
+	UpdateComment(
+		ctx context.Context,
+		req *payload.UpdateCardCommentRequest,
+	) (*presenter.CardCommentResponseWrapper, error)
+	DeleteComment(ctx context.Context, req *payload.DeleteCardCommentRequest) error
+	GetComments(
+		ctx context.Context,
+		req *payload.GetListCardCommentRequest,
+	) (*presenter.ListCardCommentResponseWrapper, error)
 }
 
 type UseCase struct {
-	CardRepo card.Repository
-	UserRepo user.Repository
+	GetClient          func(ctx context.Context) *gorm.DB
+	CardRepo           card.Repository
+	UserRepo           user.Repository
+	CardTypeRepo       cardType.Repository
+	CardListingRepo    cardListing.Repository
+	CardShareRepo      cardShare.Repository
+	TagRepo            tag.Repository
+	CardAttachmentRepo cardAttachment.Repository
+	CardRevisionRepo   cardRevision.Repository
+	CardTemplateRepo   cardTemplate.Repository
+	CardCommentRepo    cardComment.Repository
+	CardAccess         *cardAccess.Access
+	Revisions          *revision.Recorder
+	Blob               storage.Blob
+	Events             event.Publisher
 }
 
 func New(repo *repository.Repository) CardUseCase {
 	return &UseCase{
-		CardRepo: repo.Card,
-		UserRepo: repo.User,
+		GetClient:          repo.GetClient,
+		CardRepo:           repo.Card,
+		UserRepo:           repo.User,
+		CardTypeRepo:       repo.CardType,
+		CardListingRepo:    repo.CardListing,
+		CardShareRepo:      repo.CardShare,
+		TagRepo:            repo.Tag,
+		CardAttachmentRepo: repo.CardAttachment,
+		CardRevisionRepo:   repo.CardRevision,
+		CardTemplateRepo:   repo.CardTemplate,
+		CardCommentRepo:    repo.CardComment,
+		CardAccess:         cardAccess.New(repo),
+		Revisions:          revision.New(repo),
+		Blob:               repo.Blob,
+		Events:             repo.Events,
 	}
 }
 
-func (u *UseCase) validateCreate(req *payload.CreateCardRequest) error {
-	if req.NameCard == "" {
-		return customError.ErrRequestInvalidParam("name_card")
+func userIdFromContext(ctx context.Context) int64 {
+	userId, _ := ctx.Value("user_id").(int64)
+
+	return userId
+}
+
+// inTx runs fn in the transaction of ctx, or in a new one when there is none, such as outside of a batch.
+func (u *UseCase) inTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mysql.IsEnableTx(ctx) {
+		return fn(ctx)
+	}
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err := mysql.TxEnd(ctx, fn)
+
+	return err
+}
+
+// ensureNotListed rejects changes to cards held in escrow by an active marketplace listing.
+func (u *UseCase) ensureNotListed(ctx context.Context, cardId int64) error {
+	_, err := u.CardListingRepo.GetActiveByCardID(ctx, cardId)
+	if err == nil {
+		return customError.ErrModelConflict("Card")
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return customError.ErrModelGet(err, "CardListing")
+	}
+
+	return nil
+}
+
+func (u *UseCase) getCardType(ctx context.Context, code string) (*model.CardType, error) {
+	myCardType, err := u.CardTypeRepo.GetByCode(ctx, code, false)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrRequestInvalidParam("card_type")
+		}
+
+		return nil, customError.ErrModelGet(err, "CardType")
+	}
+
+	return myCardType, nil
+}
+
+// validateCardType only accepts codes which exist in the catalog and are still active.
+func (u *UseCase) validateCardType(ctx context.Context, code string) (*model.CardType, error) {
+	myCardType, err := u.getCardType(ctx, code)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.CardType == "" {
-		return customError.ErrRequestInvalidParam("card_type")
+	if !myCardType.IsActive {
+		return nil, customError.ErrRequestInvalidParam("card_type")
+	}
+
+	return myCardType, nil
+}
+
+func (u *UseCase) validateCreate(ctx context.Context, req *payload.CreateCardRequest) error {
+	if err := validation.Validate(req); err != nil {
+		return err
 	}
 
 	req.NameCard = strings.TrimSpace(req.NameCard)
-	if len(req.NameCard) == 0 {
-		req.NameCard = ""
-		return customError.ErrRequestInvalidParam("name_card")
+	req.CardType = strings.TrimSpace(req.CardType)
+
+	myCardType, err := u.validateCardType(ctx, req.CardType)
+	if err != nil {
+		return err
 	}
 
-	if len(req.CardType) == 0 {
-		return customError.ErrRequestInvalidParam("card_type")
+	attributes, err := validateAttributes(myCardType, model.JSON(req.Attributes))
+	if err != nil {
+		return err
 	}
 
-	return nil
+	req.Attributes = json.RawMessage(attributes)
+
+	return validateSchedule(req.ActiveFrom, req.ExpiresAt, req.ExpiresAt != nil)
 }
 
 func (u *UseCase) Create(
 	ctx context.Context,
 	req *payload.CreateCardRequest,
 ) (*presenter.CardResponseWrapper, error) {
-	if err := u.validateCreate(req); err != nil {
+	if req.TemplateId != 0 {
+		if err := u.applyTemplate(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.validateCreate(ctx, req); err != nil {
 		return nil, err
 	}
 
@@ -73,51 +242,90 @@ func (u *UseCase) Create(
 	}
 
 	myCard := &model.Card{
-		NameCard: req.NameCard,
-		CardType: req.CardType,
-		UserId:   myUser.ID,
+		NameCard:   req.NameCard,
+		CardType:   req.CardType,
+		Attributes: model.JSON(req.Attributes),
+		ActiveFrom: req.ActiveFrom,
+		ExpiresAt:  req.ExpiresAt,
+		UserId:     myUser.ID,
 	}
 
-	err = u.CardRepo.Create(ctx, myCard)
+	err = u.Revisions.Write(ctx, myCard, model.CardRevisionActionCreate, req.UserId, func(ctx context.Context) error {
+		// a retried transaction creates the card again
+		myCard.ID = 0
+
+		if err := u.CardRepo.Create(ctx, myCard); err != nil {
+			return customError.ErrModelCreate(err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, customError.ErrModelCreate(err)
+		return nil, err
 	}
 
 	return &presenter.CardResponseWrapper{Card: myCard}, nil
 }
 
 func (u *UseCase) validateUpdate(ctx context.Context, req *payload.UpdateCardRequest) (*model.Card, error) {
-	myCard, err := u.CardRepo.GetByID(ctx, req.ID)
+	myCard, err := u.CardAccess.Get(ctx, req.ID, policy.ActionUpdate)
 	if err != nil {
-		return nil, customError.ErrModelGet(err, "Card")
+		return nil, err
+	}
+
+	if req.Version != 0 && req.Version != myCard.Version {
+		return nil, customError.ErrPreconditionFailed()
+	}
+
+	if err = u.ensureNotListed(ctx, myCard.ID); err != nil {
+		return nil, err
+	}
+
+	if err = validation.Validate(req); err != nil {
+		return nil, err
 	}
 
 	if req.NameCard != nil {
 		*req.NameCard = strings.TrimSpace(*req.NameCard)
-		if len(*req.NameCard) == 0 {
-			return nil, customError.ErrRequestInvalidParam("name")
-		}
-
 		myCard.NameCard = *req.NameCard
 	}
 
+	var myCardType *model.CardType
+
 	if req.CardType != nil {
 		*req.CardType = strings.TrimSpace(*req.CardType)
-		if len(*req.CardType) == 0 {
-			return nil, customError.ErrRequestInvalidParam("Cardname")
+
+		if *req.CardType != myCard.CardType {
+			myCardType, err = u.validateCardType(ctx, *req.CardType)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		myCard.CardType = *req.CardType
 	}
 
-	myUser, err := u.UserRepo.GetByID(ctx, req.UserId)
+	if req.Attributes != nil {
+		myCard.Attributes = model.JSON(req.Attributes)
+	}
 
-	if err != nil {
-		return nil, customError.ErrModelGet(err, "User")
+	if err = updateSchedule(myCard, req); err != nil {
+		return nil, err
 	}
 
-	if myCard.UserId != myUser.ID {
-		return nil, customError.ErrRequestInvalidParam("userId")
+	// the attributes must also match the schema of a new card type
+	if req.Attributes != nil || myCardType != nil {
+		if myCardType == nil {
+			myCardType, err = u.getCardType(ctx, myCard.CardType)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		myCard.Attributes, err = validateAttributes(myCardType, myCard.Attributes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return myCard, nil
@@ -132,44 +340,137 @@ func (u *UseCase) Update(
 		return nil, err
 	}
 
-	err = u.CardRepo.Update(ctx, myCard)
+	err = u.Revisions.Write(ctx, myCard, model.CardRevisionActionUpdate, req.UserId, func(ctx context.Context) error {
+		err := u.CardRepo.Update(ctx, myCard)
+		switch {
+		case errors.Is(err, base.ErrStale):
+			return customError.ErrPreconditionFailed()
+		case err != nil:
+			return customError.ErrModelUpdate(err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, customError.ErrModelUpdate(err)
+		return nil, err
 	}
 
 	return &presenter.CardResponseWrapper{Card: myCard}, nil
 }
 
 func (u *UseCase) Delete(ctx context.Context, req *payload.DeleteRequest) error {
-	myCard, err := u.CardRepo.GetByID(ctx, req.ID)
-	if err != nil {
-		return customError.ErrModelGet(err, "Card")
+	if req.Permanent {
+		return u.deletePermanently(ctx, req.ID)
 	}
 
-	err = u.CardRepo.Delete(ctx, myCard, false)
+	myCard, err := u.CardAccess.Get(ctx, req.ID, policy.ActionDelete)
 	if err != nil {
-		return customError.ErrModelDelete(err)
+		return err
 	}
 
-	return nil
+	if err = u.ensureNotListed(ctx, myCard.ID); err != nil {
+		return err
+	}
+
+	userId := userIdFromContext(ctx)
+
+	// attachments stay until the card is purged from the trash
+	return u.Revisions.Write(ctx, myCard, model.CardRevisionActionDelete, userId, func(ctx context.Context) error {
+		if err := u.CardRepo.Delete(ctx, myCard, false); err != nil {
+			return customError.ErrModelDelete(err)
+		}
+
+		return nil
+	})
 }
 
 func (u *UseCase) GetList(
 	ctx context.Context,
-	req *payload.GetListRequest,
+	req *payload.GetListCardRequest,
 ) (*presenter.ListCardResponseWrapper, error) {
 	req.Format()
 
 	var (
-		order      = make([]string, 0)
-		conditions = map[string]interface{}{}
+		conditions []clause.Expression
+		owned      = clause.Eq{Column: "user_id", Value: req.UserId}
+		shared     = clause.Expr{
+			SQL:  "id IN (SELECT card_id FROM card_shares WHERE user_id = ?)",
+			Vars: []interface{}{req.UserId},
+		}
 	)
 
-	if req.OrderBy != "" {
-		order = append(order, fmt.Sprintf("%s", req.OrderBy))
+	if req.Sort == "" {
+		req.Sort = req.OrderBy
+	}
+
+	keys, err := query.Cards.ParseSort(req.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	keyset, err := pagination.NewKeyset(keys, req.Cursor, req.Page, req.Limit)
+	if err != nil {
+		return nil, customError.ErrRequestInvalidParam("cursor")
 	}
-	conditions["user_id"] = ctx.Value("user_id")
-	myCards, total, err := u.CardRepo.GetList(ctx, req.Search, req.Page, req.Limit, conditions, order)
+
+	conditions, err = query.Cards.ParseFilters(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	selection, err := query.Cards.ParseSelection(req.Fields, req.Include, req.IncludeSet)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(req.Scope)) {
+	case "", payload.CardScopeOwned:
+		conditions = append(conditions, owned)
+	case payload.CardScopeShared:
+		conditions = append(conditions, shared)
+	case payload.CardScopeAll:
+		conditions = append(conditions, clause.Or(owned, shared))
+	default:
+		return nil, customError.ErrRequestInvalidParam("scope")
+	}
+
+	attrConditions, err := attributeConditions(req.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions = append(conditions, attrConditions...)
+
+	if req.Tags != "" {
+		tags, err := normalizeTags(strings.Split(req.Tags, ","))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(tags) > 0 {
+			condition, err := tagCondition(tags, req.TagsMode)
+			if err != nil {
+				return nil, err
+			}
+
+			conditions = append(conditions, condition)
+		}
+	}
+
+	var inactiveOwnerId int64
+	if req.IncludeInactive {
+		inactiveOwnerId = req.UserId
+	}
+
+	myCards, total, page, err := u.CardRepo.GetList(
+		ctx,
+		req.Search,
+		conditions,
+		keyset,
+		req.SkipCount,
+		inactiveOwnerId,
+		selection,
+	)
 	if err != nil {
 		return nil, customError.ErrModelGet(err, "Card")
 	}
@@ -178,23 +479,16 @@ func (u *UseCase) GetList(
 		req.Page = 1
 	}
 	return &presenter.ListCardResponseWrapper{
-		Cards: myCards,
-		Meta: map[string]interface{}{
-			"page":  req.Page,
-			"limit": req.Limit,
-			"total": total,
-		},
+		Cards:  myCards,
+		Meta:   pagination.Meta(req.Page, req.Limit, total, page),
+		Fields: selection.Keys(),
 	}, nil
 }
 
 func (u *UseCase) GetByID(ctx context.Context, req *payload.GetByIDRequest) (*presenter.CardResponseWrapper, error) {
-	myCard, err := u.CardRepo.GetByID(ctx, req.ID)
+	myCard, err := u.CardAccess.Get(ctx, req.ID, policy.ActionView)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, customError.ErrModelNotFound()
-		}
-
-		return nil, customError.ErrModelGet(err, "Card")
+		return nil, err
 	}
 
 	return &presenter.CardResponseWrapper{Card: myCard}, nil
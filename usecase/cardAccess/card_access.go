@@ -0,0 +1,104 @@
+package cardAccess
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/policy"
+	"myapp/repository"
+	"myapp/repository/card"
+	"myapp/repository/cardShare"
+)
+
+// Access authorizes cards for the use cases which work on cards of other users, it reads the principal from the context.
+type Access struct {
+	CardRepo      card.Repository
+	CardShareRepo cardShare.Repository
+}
+
+func New(repo *repository.Repository) *Access {
+	return &Access{
+		CardRepo:      repo.Card,
+		CardShareRepo: repo.CardShare,
+	}
+}
+
+// Get loads a card and checks the action against the card policy, either as its owner or through a share.
+func (a *Access) Get(ctx context.Context, id int64, action policy.Action) (*model.Card, error) {
+	myCard, err := a.CardRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "Card")
+	}
+
+	if err = a.Authorize(ctx, myCard, action); err != nil {
+		return nil, err
+	}
+
+	return myCard, nil
+}
+
+// Authorize checks the action on a card which is already loaded.
+func (a *Access) Authorize(ctx context.Context, myCard *model.Card, action policy.Action) error {
+	sharedWith, err := a.SharedWith(ctx, myCard)
+	if err != nil {
+		return err
+	}
+
+	return policy.Can(policy.PrincipalFromContext(ctx), action, policy.NewCardResource(myCard, sharedWith))
+}
+
+// SharedWith returns the permission the card was shared with the user of the context, owners and strangers get none.
+func (a *Access) SharedWith(ctx context.Context, myCard *model.Card) (string, error) {
+	userId := policy.PrincipalFromContext(ctx).UserId
+	if myCard.UserId == userId {
+		return "", nil
+	}
+
+	share, err := a.CardShareRepo.GetByCardAndUser(ctx, myCard.ID, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+
+		return "", customError.ErrModelGet(err, "CardShare")
+	}
+
+	return share.Permission, nil
+}
+
+// Viewable tells which of the cards the user of the context may view, all of their shares are read at once.
+func (a *Access) Viewable(ctx context.Context, myCards []*model.Card) (map[int64]bool, error) {
+	principal := policy.PrincipalFromContext(ctx)
+
+	cardIds := make([]int64, 0, len(myCards))
+	for i := range myCards {
+		if myCards[i].UserId != principal.UserId {
+			cardIds = append(cardIds, myCards[i].ID)
+		}
+	}
+
+	shares, err := a.CardShareRepo.GetListByUserAndCards(ctx, principal.UserId, cardIds)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardShare")
+	}
+
+	sharedWith := make(map[int64]string, len(shares))
+	for i := range shares {
+		sharedWith[shares[i].CardId] = shares[i].Permission
+	}
+
+	viewable := make(map[int64]bool, len(myCards))
+	for i := range myCards {
+		resource := policy.NewCardResource(myCards[i], sharedWith[myCards[i].ID])
+		viewable[myCards[i].ID] = policy.Can(principal, policy.ActionView, resource) == nil
+	}
+
+	return viewable, nil
+}
@@ -0,0 +1,390 @@
+package cardListing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/config"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/mysql"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/repository"
+	"myapp/repository/card"
+	"myapp/repository/cardListing"
+	"myapp/repository/cardShare"
+	"myapp/repository/cardTransfer"
+	"myapp/repository/user"
+	"myapp/usecase/revision"
+)
+
+type CardListingUseCase interface {
+	Create(ctx context.Context, req *payload.CreateCardListingRequest) (*presenter.CardListingResponseWrapper, error)
+	Buy(ctx context.Context, req *payload.CardListingActionRequest) (*presenter.CardListingResponseWrapper, error)
+	Cancel(ctx context.Context, req *payload.CardListingActionRequest) (*presenter.CardListingResponseWrapper, error)
+	GetByID(ctx context.Context, req *payload.CardListingActionRequest) (*presenter.CardListingResponseWrapper, error)
+	GetList(ctx context.Context, req *payload.GetListCardListingRequest) (*presenter.ListCardListingResponseWrapper, error)
+	GetHistory(ctx context.Context, req *payload.GetListCardListingRequest) (*presenter.ListCardListingResponseWrapper, error)
+}
+
+type UseCase struct {
+	GetClient        func(ctx context.Context) *gorm.DB
+	CardRepo         card.Repository
+	UserRepo         user.Repository
+	CardListingRepo  cardListing.Repository
+	CardTransferRepo cardTransfer.Repository
+	CardShareRepo    cardShare.Repository
+	Revisions        *revision.Recorder
+}
+
+func New(repo *repository.Repository) CardListingUseCase {
+	return &UseCase{
+		GetClient:        repo.GetClient,
+		CardRepo:         repo.Card,
+		UserRepo:         repo.User,
+		CardListingRepo:  repo.CardListing,
+		CardTransferRepo: repo.CardTransfer,
+		CardShareRepo:    repo.CardShare,
+		Revisions:        revision.New(repo),
+	}
+}
+
+// fee is the part of the price kept by the marketplace, rounded down.
+func fee(price int) int {
+	return price * config.GetConfig().Market.FeePercent / 100
+}
+
+func (u *UseCase) Create(
+	ctx context.Context,
+	req *payload.CreateCardListingRequest,
+) (*presenter.CardListingResponseWrapper, error) {
+	if req.Price <= 0 {
+		return nil, customError.ErrRequestInvalidParam("price")
+	}
+
+	var myListing *model.CardListing
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err := mysql.TxEnd(ctx, func(ctx context.Context) error {
+		myCard, err := u.CardRepo.GetByIDForUpdate(ctx, req.CardId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customError.ErrModelNotFound()
+			}
+
+			return customError.ErrModelGet(err, "Card")
+		}
+
+		if myCard.UserId != req.UserId {
+			return customError.ErrModelNotFound()
+		}
+
+		_, err = u.CardListingRepo.GetActiveByCardID(ctx, myCard.ID)
+		if err == nil {
+			return customError.ErrModelConflict("Card")
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrModelGet(err, "CardListing")
+		}
+
+		_, err = u.CardTransferRepo.GetPendingByCardID(ctx, myCard.ID)
+		if err == nil {
+			return customError.ErrModelConflict("Card")
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrModelGet(err, "CardTransfer")
+		}
+
+		myListing = &model.CardListing{
+			CardId:   myCard.ID,
+			SellerId: req.UserId,
+			Price:    req.Price,
+			Status:   model.CardListingStatusActive,
+		}
+
+		err = u.CardListingRepo.Create(ctx, myListing)
+		if err != nil {
+			return customError.ErrModelCreate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.CardListingResponseWrapper{Listing: myListing}, nil
+}
+
+// lockUsers locks both users in id order so concurrent purchases between the same users can't deadlock each other.
+func (u *UseCase) lockUsers(ctx context.Context, firstId int64, secondId int64) (*model.User, *model.User, error) {
+	ids := []int64{firstId, secondId}
+	if secondId < firstId {
+		ids = []int64{secondId, firstId}
+	}
+
+	users := make(map[int64]*model.User, len(ids))
+	for _, id := range ids {
+		myUser, err := u.UserRepo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, nil, customError.ErrModelGet(err, "User")
+		}
+
+		users[id] = myUser
+	}
+
+	return users[firstId], users[secondId], nil
+}
+
+func (u *UseCase) Buy(
+	ctx context.Context,
+	req *payload.CardListingActionRequest,
+) (*presenter.CardListingResponseWrapper, error) {
+	var myListing *model.CardListing
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err := mysql.TxEnd(ctx, func(ctx context.Context) error {
+		var err error
+
+		myListing, err = u.CardListingRepo.GetByIDForUpdate(ctx, req.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customError.ErrModelNotFound()
+			}
+
+			return customError.ErrModelGet(err, "CardListing")
+		}
+
+		if myListing.Status != model.CardListingStatusActive {
+			return customError.ErrModelConflict("CardListing")
+		}
+
+		if myListing.SellerId == req.UserId {
+			return customError.ErrRequestInvalidParam("id")
+		}
+
+		myCard, err := u.CardRepo.GetByIDForUpdate(ctx, myListing.CardId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customError.ErrModelConflict("Card")
+			}
+
+			return customError.ErrModelGet(err, "Card")
+		}
+
+		if myCard.UserId != myListing.SellerId {
+			return customError.ErrModelConflict("Card")
+		}
+
+		buyer, seller, err := u.lockUsers(ctx, req.UserId, myListing.SellerId)
+		if err != nil {
+			return err
+		}
+
+		if buyer.Score < myListing.Price {
+			return customError.ErrRequestInvalidParam("score")
+		}
+
+		var (
+			now       = time.Now()
+			marketFee = fee(myListing.Price)
+		)
+
+		buyer.Score -= myListing.Price
+		seller.Score += myListing.Price - marketFee
+
+		if err = u.UserRepo.Update(ctx, buyer); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		if err = u.UserRepo.Update(ctx, seller); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		myCard.UserId = buyer.ID
+
+		err = u.Revisions.Write(ctx, myCard, model.CardRevisionActionSale, buyer.ID, func(ctx context.Context) error {
+			if err := u.CardRepo.Update(ctx, myCard); err != nil {
+				return customError.ErrModelUpdate(err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// collaborators of the previous owner don't keep access to the card
+		if err = u.CardShareRepo.DeleteByCardID(ctx, myCard.ID); err != nil {
+			return customError.ErrModelDelete(err)
+		}
+
+		myListing.Status = model.CardListingStatusSold
+		myListing.BuyerId = &buyer.ID
+		myListing.Fee = marketFee
+		myListing.SoldAt = &now
+
+		if err = u.CardListingRepo.Update(ctx, myListing); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.CardListingResponseWrapper{Listing: myListing}, nil
+}
+
+func (u *UseCase) Cancel(
+	ctx context.Context,
+	req *payload.CardListingActionRequest,
+) (*presenter.CardListingResponseWrapper, error) {
+	var myListing *model.CardListing
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err := mysql.TxEnd(ctx, func(ctx context.Context) error {
+		var err error
+
+		myListing, err = u.CardListingRepo.GetByIDForUpdate(ctx, req.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customError.ErrModelNotFound()
+			}
+
+			return customError.ErrModelGet(err, "CardListing")
+		}
+
+		if myListing.SellerId != req.UserId {
+			return customError.ErrNoPermission()
+		}
+
+		if myListing.Status != model.CardListingStatusActive {
+			return customError.ErrModelConflict("CardListing")
+		}
+
+		now := time.Now()
+		myListing.Status = model.CardListingStatusCancelled
+		myListing.CancelledAt = &now
+
+		if err = u.CardListingRepo.Update(ctx, myListing); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.CardListingResponseWrapper{Listing: myListing}, nil
+}
+
+func (u *UseCase) GetByID(
+	ctx context.Context,
+	req *payload.CardListingActionRequest,
+) (*presenter.CardListingResponseWrapper, error) {
+	myListing, err := u.CardListingRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "CardListing")
+	}
+
+	// finished listings are only part of the history of their seller and buyer
+	isParticipant := myListing.SellerId == req.UserId ||
+		(myListing.BuyerId != nil && *myListing.BuyerId == req.UserId)
+	if myListing.Status != model.CardListingStatusActive && !isParticipant {
+		return nil, customError.ErrModelNotFound()
+	}
+
+	return &presenter.CardListingResponseWrapper{Listing: myListing}, nil
+}
+
+func (u *UseCase) getList(
+	ctx context.Context,
+	req *payload.GetListCardListingRequest,
+	conditions []clause.Expression,
+) (*presenter.ListCardListingResponseWrapper, error) {
+	req.Format()
+
+	order := []string{"id DESC"}
+	if req.OrderBy != "" {
+		order = []string{fmt.Sprintf("%s", req.OrderBy)}
+	}
+
+	myListings, total, err := u.CardListingRepo.GetList(
+		ctx, req.Search, req.Page, req.Limit, clause.And(conditions...), order,
+	)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardListing")
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListCardListingResponseWrapper{
+		Listings: myListings,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
+
+func (u *UseCase) GetList(
+	ctx context.Context,
+	req *payload.GetListCardListingRequest,
+) (*presenter.ListCardListingResponseWrapper, error) {
+	conditions := []clause.Expression{
+		clause.Eq{Column: "status", Value: model.CardListingStatusActive},
+	}
+
+	if cardType := strings.TrimSpace(req.CardType); cardType != "" {
+		conditions = append(conditions, clause.Expr{
+			SQL:  "card_id IN (SELECT id FROM cards WHERE card_type = ? AND deleted_at IS NULL)",
+			Vars: []interface{}{cardType},
+		})
+	}
+
+	return u.getList(ctx, req, conditions)
+}
+
+func (u *UseCase) GetHistory(
+	ctx context.Context,
+	req *payload.GetListCardListingRequest,
+) (*presenter.ListCardListingResponseWrapper, error) {
+	conditions := make([]clause.Expression, 0)
+
+	switch strings.ToLower(strings.TrimSpace(req.Role)) {
+	case "seller":
+		conditions = append(conditions, clause.Eq{Column: "seller_id", Value: req.UserId})
+	case "buyer":
+		conditions = append(conditions, clause.Eq{Column: "buyer_id", Value: req.UserId})
+	case "":
+		conditions = append(conditions, clause.Or(
+			clause.Eq{Column: "seller_id", Value: req.UserId},
+			clause.Eq{Column: "buyer_id", Value: req.UserId},
+		))
+	default:
+		return nil, customError.ErrRequestInvalidParam("role")
+	}
+
+	if req.Status != "" {
+		conditions = append(conditions, clause.Eq{Column: "status", Value: strings.ToLower(req.Status)})
+	}
+
+	return u.getList(ctx, req, conditions)
+}
@@ -0,0 +1,146 @@
+package cardShare
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/payload"
+	"myapp/policy"
+	"myapp/presenter"
+	"myapp/repository"
+	"myapp/repository/cardShare"
+	"myapp/repository/user"
+	"myapp/usecase/cardAccess"
+)
+
+type CardShareUseCase interface {
+	Share(ctx context.Context, req *payload.ShareCardRequest) (*presenter.CardShareResponseWrapper, error)
+	GetList(ctx context.Context, req *payload.GetListCardShareRequest) (*presenter.ListCardShareResponseWrapper, error)
+	Revoke(ctx context.Context, req *payload.RevokeCardShareRequest) error
+}
+
+type UseCase struct {
+	UserRepo      user.Repository
+	CardShareRepo cardShare.Repository
+	CardAccess    *cardAccess.Access
+}
+
+func New(repo *repository.Repository) CardShareUseCase {
+	return &UseCase{
+		UserRepo:      repo.User,
+		CardShareRepo: repo.CardShare,
+		CardAccess:    cardAccess.New(repo),
+	}
+}
+
+func (u *UseCase) validateShare(ctx context.Context, req *payload.ShareCardRequest) error {
+	req.Permission = strings.ToLower(strings.TrimSpace(req.Permission))
+	if req.Permission == "" {
+		req.Permission = model.CardPermissionViewer
+	}
+
+	if !model.IsValidSharePermission(req.Permission) {
+		return customError.ErrRequestInvalidParam("permission")
+	}
+
+	if req.TargetUserId == 0 || req.TargetUserId == req.UserId {
+		return customError.ErrRequestInvalidParam("user_id")
+	}
+
+	_, err := u.UserRepo.GetByID(ctx, req.TargetUserId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrRequestInvalidParam("user_id")
+		}
+
+		return customError.ErrModelGet(err, "User")
+	}
+
+	return nil
+}
+
+func (u *UseCase) Share(
+	ctx context.Context,
+	req *payload.ShareCardRequest,
+) (*presenter.CardShareResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionShare)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.validateShare(ctx, req); err != nil {
+		return nil, err
+	}
+
+	myShare, err := u.CardShareRepo.GetByCardAndUser(ctx, myCard.ID, req.TargetUserId)
+	switch {
+	case err == nil:
+		myShare.Permission = req.Permission
+
+		err = u.CardShareRepo.Update(ctx, myShare)
+		if err != nil {
+			return nil, customError.ErrModelUpdate(err)
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		myShare = &model.CardShare{
+			CardId:     myCard.ID,
+			UserId:     req.TargetUserId,
+			Permission: req.Permission,
+		}
+
+		err = u.CardShareRepo.Create(ctx, myShare)
+		if err != nil {
+			return nil, customError.ErrModelCreate(err)
+		}
+	default:
+		return nil, customError.ErrModelGet(err, "CardShare")
+	}
+
+	return &presenter.CardShareResponseWrapper{Share: myShare}, nil
+}
+
+func (u *UseCase) GetList(
+	ctx context.Context,
+	req *payload.GetListCardShareRequest,
+) (*presenter.ListCardShareResponseWrapper, error) {
+	myCard, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionShare)
+	if err != nil {
+		return nil, err
+	}
+
+	myShares, err := u.CardShareRepo.GetListByCard(ctx, myCard.ID)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardShare")
+	}
+
+	return &presenter.ListCardShareResponseWrapper{Shares: myShares}, nil
+}
+
+// Revoke removes a share, either by the owner of the card or by the collaborator leaving it.
+func (u *UseCase) Revoke(ctx context.Context, req *payload.RevokeCardShareRequest) error {
+	if req.TargetUserId != req.UserId {
+		if _, err := u.CardAccess.Get(ctx, req.CardId, policy.ActionShare); err != nil {
+			return err
+		}
+	}
+
+	myShare, err := u.CardShareRepo.GetByCardAndUser(ctx, req.CardId, req.TargetUserId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrModelNotFound()
+		}
+
+		return customError.ErrModelGet(err, "CardShare")
+	}
+
+	err = u.CardShareRepo.Delete(ctx, myShare)
+	if err != nil {
+		return customError.ErrModelDelete(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package cardStat
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"myapp/config"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/mysql"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/repository"
+	"myapp/repository/cardStat"
+)
+
+const (
+	dayLayout = "2006-01-02"
+
+	// upsertBatchSize bounds the rows written by one statement of the aggregation.
+	upsertBatchSize = 500
+	// maxPeriods bounds the trend of one request, a year of days.
+	maxPeriods = 366
+)
+
+// defaultPeriods is how many periods the trend covers when no from date is given.
+var defaultPeriods = map[string]int{
+	payload.StatsIntervalDay:   30,
+	payload.StatsIntervalWeek:  12,
+	payload.StatsIntervalMonth: 12,
+}
+
+type CardStatUseCase interface {
+	Aggregate(ctx context.Context) error
+	GetStats(ctx context.Context, req *payload.GetCardStatsRequest) (*presenter.CardStatsResponseWrapper, error)
+}
+
+type UseCase struct {
+	GetClient    func(ctx context.Context) *gorm.DB
+	CardStatRepo cardStat.Repository
+}
+
+func New(repo *repository.Repository) CardStatUseCase {
+	return &UseCase{
+		GetClient:    repo.GetClient,
+		CardStatRepo: repo.CardStat,
+	}
+}
+
+func location() (*time.Location, error) {
+	return time.LoadLocation(config.GetConfig().Stats.Timezone)
+}
+
+// Aggregate refreshes the rollups, the type counts are rebuilt and the days from the last rolled up one on are recounted.
+func (u *UseCase) Aggregate(ctx context.Context) error {
+	loc, err := location()
+	if err != nil {
+		return err
+	}
+
+	txCtx := mysql.TxBegin(ctx, u.GetClient)
+	_, err = mysql.TxEnd(txCtx, func(ctx context.Context) error {
+		if err := u.CardStatRepo.RebuildTypeCounts(ctx); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lastDay, err := u.CardStatRepo.GetLastDay(ctx)
+	if err != nil {
+		return customError.ErrModelGet(err, "CardDailyCreation")
+	}
+
+	// the last day may still have been running when it was rolled up
+	var since time.Time
+	if lastDay != "" {
+		if since, err = time.ParseInLocation(dayLayout, lastDay, loc); err != nil {
+			return err
+		}
+	}
+
+	type key struct {
+		userId   int64
+		day      string
+		cardType string
+	}
+
+	counts := make(map[key]int64)
+	err = u.CardStatRepo.ForEachCreatedSince(ctx, since, func(userId int64, cardType string, createdAt time.Time) error {
+		counts[key{userId, createdAt.In(loc).Format(dayLayout), cardType}]++
+		return nil
+	})
+	if err != nil {
+		return customError.ErrModelGet(err, "Card")
+	}
+
+	rows := make([]model.CardDailyCreation, 0, upsertBatchSize)
+	for k, created := range counts {
+		rows = append(rows, model.CardDailyCreation{
+			UserId:   k.userId,
+			Day:      k.day,
+			CardType: k.cardType,
+			Created:  created,
+		})
+
+		if len(rows) == upsertBatchSize {
+			if err = u.CardStatRepo.UpsertDailyCreations(ctx, rows); err != nil {
+				return customError.ErrModelUpdate(err)
+			}
+
+			rows = rows[:0]
+		}
+	}
+
+	if err = u.CardStatRepo.UpsertDailyCreations(ctx, rows); err != nil {
+		return customError.ErrModelUpdate(err)
+	}
+
+	return nil
+}
+
+// periodStart returns the first day of the period holding day, weeks start on monday.
+func periodStart(day time.Time, interval string) time.Time {
+	switch interval {
+	case payload.StatsIntervalWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case payload.StatsIntervalMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	default:
+		return day
+	}
+}
+
+func nextPeriod(start time.Time, interval string) time.Time {
+	switch interval {
+	case payload.StatsIntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case payload.StatsIntervalMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+func (u *UseCase) validateGetStats(req *payload.GetCardStatsRequest, loc *time.Location) (time.Time, time.Time, error) {
+	var from, to time.Time
+
+	req.Interval = strings.ToLower(strings.TrimSpace(req.Interval))
+	if req.Interval == "" {
+		req.Interval = payload.StatsIntervalDay
+	}
+
+	periods, ok := defaultPeriods[req.Interval]
+	if !ok {
+		return from, to, customError.ErrRequestInvalidParam("interval")
+	}
+
+	now := time.Now().In(loc)
+	to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if req.To != "" {
+		day, err := time.ParseInLocation(dayLayout, req.To, loc)
+		if err != nil {
+			return from, to, customError.ErrRequestInvalidParam("to")
+		}
+
+		to = day
+	}
+
+	from = periodStart(to, req.Interval)
+	for i := 1; i < periods; i++ {
+		from = periodStart(from.AddDate(0, 0, -1), req.Interval)
+	}
+
+	if req.From != "" {
+		day, err := time.ParseInLocation(dayLayout, req.From, loc)
+		if err != nil || day.After(to) {
+			return from, to, customError.ErrRequestInvalidParam("from")
+		}
+
+		from = day
+	}
+
+	periods = 0
+	for start := periodStart(from, req.Interval); !start.After(to); start = nextPeriod(start, req.Interval) {
+		periods++
+		if periods > maxPeriods {
+			return from, to, customError.ErrRequestInvalidParam("from")
+		}
+	}
+
+	return from, to, nil
+}
+
+// GetStats only reads the rollups, they are as fresh as the last aggregation.
+func (u *UseCase) GetStats(
+	ctx context.Context,
+	req *payload.GetCardStatsRequest,
+) (*presenter.CardStatsResponseWrapper, error) {
+	loc, err := location()
+	if err != nil {
+		return nil, customError.ErrGet(err)
+	}
+
+	from, to, err := u.validateGetStats(req, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	byType, err := u.CardStatRepo.GetTypeCounts(ctx, req.UserId)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardTypeCount")
+	}
+
+	days, err := u.CardStatRepo.GetDailyCreations(ctx, req.UserId, from.Format(dayLayout), to.Format(dayLayout))
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardDailyCreation")
+	}
+
+	created := make(map[string]int64, len(days))
+	for i := range days {
+		day, err := time.ParseInLocation(dayLayout, days[i].Day, loc)
+		if err != nil {
+			return nil, customError.ErrModelGet(err, "CardDailyCreation")
+		}
+
+		created[periodStart(day, req.Interval).Format(dayLayout)] += days[i].Created
+	}
+
+	// every period of the range is listed, also the empty ones
+	trend := make([]presenter.CardTrendPoint, 0)
+	for start := periodStart(from, req.Interval); !start.After(to); start = nextPeriod(start, req.Interval) {
+		period := start.Format(dayLayout)
+		trend = append(trend, presenter.CardTrendPoint{Period: period, Count: created[period]})
+	}
+
+	return &presenter.CardStatsResponseWrapper{
+		Timezone: loc.String(),
+		Interval: req.Interval,
+		From:     from.Format(dayLayout),
+		To:       to.Format(dayLayout),
+		ByType:   byType,
+		Trend:    trend,
+	}, nil
+}
@@ -0,0 +1,354 @@
+package cardTransfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/config"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/mysql"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/repository"
+	"myapp/repository/card"
+	"myapp/repository/cardListing"
+	"myapp/repository/cardShare"
+	"myapp/repository/cardTransfer"
+	"myapp/repository/user"
+	"myapp/usecase/revision"
+)
+
+type CardTransferUseCase interface {
+	Create(ctx context.Context, req *payload.CreateCardTransferRequest) (*presenter.CardTransferResponseWrapper, error)
+	Accept(ctx context.Context, req *payload.RespondCardTransferRequest) (*presenter.CardTransferResponseWrapper, error)
+	Decline(ctx context.Context, req *payload.RespondCardTransferRequest) (*presenter.CardTransferResponseWrapper, error)
+	Cancel(ctx context.Context, req *payload.RespondCardTransferRequest) (*presenter.CardTransferResponseWrapper, error)
+	GetByID(ctx context.Context, req *payload.RespondCardTransferRequest) (*presenter.CardTransferResponseWrapper, error)
+	GetList(ctx context.Context, req *payload.GetListCardTransferRequest) (*presenter.ListCardTransferResponseWrapper, error)
+	ExpirePending(ctx context.Context) error
+}
+
+type UseCase struct {
+	GetClient        func(ctx context.Context) *gorm.DB
+	CardRepo         card.Repository
+	UserRepo         user.Repository
+	CardTransferRepo cardTransfer.Repository
+	CardListingRepo  cardListing.Repository
+	CardShareRepo    cardShare.Repository
+	Revisions        *revision.Recorder
+}
+
+func New(repo *repository.Repository) CardTransferUseCase {
+	return &UseCase{
+		GetClient:        repo.GetClient,
+		CardRepo:         repo.Card,
+		UserRepo:         repo.User,
+		CardTransferRepo: repo.CardTransfer,
+		CardListingRepo:  repo.CardListing,
+		CardShareRepo:    repo.CardShare,
+		Revisions:        revision.New(repo),
+	}
+}
+
+func (u *UseCase) validateCreate(ctx context.Context, req *payload.CreateCardTransferRequest) error {
+	if req.ToUserId == 0 || req.ToUserId == req.UserId {
+		return customError.ErrRequestInvalidParam("to_user_id")
+	}
+
+	_, err := u.UserRepo.GetByID(ctx, req.ToUserId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrRequestInvalidParam("to_user_id")
+		}
+
+		return customError.ErrModelGet(err, "User")
+	}
+
+	return nil
+}
+
+func (u *UseCase) Create(
+	ctx context.Context,
+	req *payload.CreateCardTransferRequest,
+) (*presenter.CardTransferResponseWrapper, error) {
+	if err := u.validateCreate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	var myTransfer *model.CardTransfer
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err := mysql.TxEnd(ctx, func(ctx context.Context) error {
+		myCard, err := u.CardRepo.GetByIDForUpdate(ctx, req.CardId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customError.ErrModelNotFound()
+			}
+
+			return customError.ErrModelGet(err, "Card")
+		}
+
+		if myCard.UserId != req.UserId {
+			return customError.ErrModelNotFound()
+		}
+
+		_, err = u.CardTransferRepo.GetPendingByCardID(ctx, myCard.ID)
+		if err == nil {
+			return customError.ErrModelConflict("Card")
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrModelGet(err, "CardTransfer")
+		}
+
+		// listed cards are held in escrow by the marketplace
+		_, err = u.CardListingRepo.GetActiveByCardID(ctx, myCard.ID)
+		if err == nil {
+			return customError.ErrModelConflict("Card")
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrModelGet(err, "CardListing")
+		}
+
+		myTransfer = &model.CardTransfer{
+			CardId:     myCard.ID,
+			FromUserId: req.UserId,
+			ToUserId:   req.ToUserId,
+			Status:     model.CardTransferStatusPending,
+			ExpiresAt:  time.Now().Add(config.GetConfig().Card.TransferTTL),
+		}
+
+		err = u.CardTransferRepo.Create(ctx, myTransfer)
+		if err != nil {
+			return customError.ErrModelCreate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.CardTransferResponseWrapper{Transfer: myTransfer}, nil
+}
+
+// respond locks the transfer, checks it is still pending and lets apply move it to its next state.
+func (u *UseCase) respond(
+	ctx context.Context,
+	req *payload.RespondCardTransferRequest,
+	apply func(ctx context.Context, transfer *model.CardTransfer, now time.Time) error,
+) (*presenter.CardTransferResponseWrapper, error) {
+	var myTransfer *model.CardTransfer
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err := mysql.TxEnd(ctx, func(ctx context.Context) error {
+		var (
+			err error
+			now = time.Now()
+		)
+
+		myTransfer, err = u.CardTransferRepo.GetByIDForUpdate(ctx, req.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customError.ErrModelNotFound()
+			}
+
+			return customError.ErrModelGet(err, "CardTransfer")
+		}
+
+		if myTransfer.FromUserId != req.UserId && myTransfer.ToUserId != req.UserId {
+			return customError.ErrModelNotFound()
+		}
+
+		if myTransfer.Status != model.CardTransferStatusPending || myTransfer.IsExpired(now) {
+			return customError.ErrModelConflict("CardTransfer")
+		}
+
+		if err = apply(ctx, myTransfer, now); err != nil {
+			return err
+		}
+
+		myTransfer.RespondedAt = &now
+
+		err = u.CardTransferRepo.Update(ctx, myTransfer)
+		if err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.CardTransferResponseWrapper{Transfer: myTransfer}, nil
+}
+
+func (u *UseCase) Accept(
+	ctx context.Context,
+	req *payload.RespondCardTransferRequest,
+) (*presenter.CardTransferResponseWrapper, error) {
+	return u.respond(ctx, req, func(ctx context.Context, transfer *model.CardTransfer, now time.Time) error {
+		if transfer.ToUserId != req.UserId {
+			return customError.ErrNoPermission()
+		}
+
+		myCard, err := u.CardRepo.GetByIDForUpdate(ctx, transfer.CardId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customError.ErrModelConflict("Card")
+			}
+
+			return customError.ErrModelGet(err, "Card")
+		}
+
+		if myCard.UserId != transfer.FromUserId {
+			return customError.ErrModelConflict("Card")
+		}
+
+		myCard.UserId = transfer.ToUserId
+
+		err = u.Revisions.Write(ctx, myCard, model.CardRevisionActionTransfer, req.UserId, func(ctx context.Context) error {
+			if err := u.CardRepo.Update(ctx, myCard); err != nil {
+				return customError.ErrModelUpdate(err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// collaborators of the previous owner don't keep access to the card
+		if err = u.CardShareRepo.DeleteByCardID(ctx, myCard.ID); err != nil {
+			return customError.ErrModelDelete(err)
+		}
+
+		transfer.Status = model.CardTransferStatusAccepted
+
+		return nil
+	})
+}
+
+func (u *UseCase) Decline(
+	ctx context.Context,
+	req *payload.RespondCardTransferRequest,
+) (*presenter.CardTransferResponseWrapper, error) {
+	return u.respond(ctx, req, func(ctx context.Context, transfer *model.CardTransfer, now time.Time) error {
+		if transfer.ToUserId != req.UserId {
+			return customError.ErrNoPermission()
+		}
+
+		transfer.Status = model.CardTransferStatusDeclined
+
+		return nil
+	})
+}
+
+func (u *UseCase) Cancel(
+	ctx context.Context,
+	req *payload.RespondCardTransferRequest,
+) (*presenter.CardTransferResponseWrapper, error) {
+	return u.respond(ctx, req, func(ctx context.Context, transfer *model.CardTransfer, now time.Time) error {
+		if transfer.FromUserId != req.UserId {
+			return customError.ErrNoPermission()
+		}
+
+		transfer.Status = model.CardTransferStatusCancelled
+
+		return nil
+	})
+}
+
+func (u *UseCase) GetByID(
+	ctx context.Context,
+	req *payload.RespondCardTransferRequest,
+) (*presenter.CardTransferResponseWrapper, error) {
+	myTransfer, err := u.CardTransferRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "CardTransfer")
+	}
+
+	if myTransfer.FromUserId != req.UserId && myTransfer.ToUserId != req.UserId {
+		return nil, customError.ErrModelNotFound()
+	}
+
+	return &presenter.CardTransferResponseWrapper{Transfer: myTransfer}, nil
+}
+
+func (u *UseCase) GetList(
+	ctx context.Context,
+	req *payload.GetListCardTransferRequest,
+) (*presenter.ListCardTransferResponseWrapper, error) {
+	req.Format()
+
+	var (
+		order      = []string{"id DESC"}
+		conditions = make([]clause.Expression, 0)
+	)
+
+	if req.OrderBy != "" {
+		order = []string{fmt.Sprintf("%s", req.OrderBy)}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(req.Direction)) {
+	case "incoming":
+		conditions = append(conditions, clause.Eq{Column: "to_user_id", Value: req.UserId})
+	case "outgoing":
+		conditions = append(conditions, clause.Eq{Column: "from_user_id", Value: req.UserId})
+	case "":
+		conditions = append(conditions, clause.Or(
+			clause.Eq{Column: "to_user_id", Value: req.UserId},
+			clause.Eq{Column: "from_user_id", Value: req.UserId},
+		))
+	default:
+		return nil, customError.ErrRequestInvalidParam("direction")
+	}
+
+	if req.Status != "" {
+		conditions = append(conditions, clause.Eq{Column: "status", Value: strings.ToLower(req.Status)})
+	}
+
+	myTransfers, total, err := u.CardTransferRepo.GetList(
+		ctx, req.Search, req.Page, req.Limit, clause.And(conditions...), order,
+	)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardTransfer")
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListCardTransferResponseWrapper{
+		Transfers: myTransfers,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
+
+func (u *UseCase) ExpirePending(ctx context.Context) error {
+	total, err := u.CardTransferRepo.ExpirePending(ctx, time.Now())
+	if err != nil {
+		return customError.ErrModelUpdate(err)
+	}
+
+	if total > 0 {
+		fmt.Println("EXPIRED CARD TRANSFERS: ", total)
+	}
+
+	return nil
+}
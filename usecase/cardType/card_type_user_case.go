@@ -0,0 +1,293 @@
+package cardType
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/repository"
+	"myapp/repository/card"
+	"myapp/repository/cardType"
+	"myapp/teq"
+	"strings"
+)
+
+type CardTypeUseCase interface {
+	Create(ctx context.Context, req *payload.CreateCardTypeRequest) (*presenter.CardTypeResponseWrapper, error)
+	Update(ctx context.Context, req *payload.UpdateCardTypeRequest) (*presenter.CardTypeResponseWrapper, error)
+	GetByID(ctx context.Context, req *payload.GetByIDRequest) (*presenter.CardTypeResponseWrapper, error)
+	GetList(ctx context.Context, req *payload.GetListRequest) (*presenter.ListCardTypeResponseWrapper, error)
+	GetActiveList(ctx context.Context, req *payload.GetListRequest) (*presenter.ListCardTypeResponseWrapper, error)
+	Delete(ctx context.Context, req *payload.DeleteRequest) error
+}
+
+type UseCase struct {
+	CardTypeRepo cardType.Repository
+	CardRepo     card.Repository
+}
+
+func New(repo *repository.Repository) CardTypeUseCase {
+	return &UseCase{
+		CardTypeRepo: repo.CardType,
+		CardRepo:     repo.Card,
+	}
+}
+
+// ensureUnused refuses to rename or delete a card type that cards still refer to by its code.
+func (u *UseCase) ensureUnused(ctx context.Context, myCardType *model.CardType) error {
+	used, err := u.CardRepo.IsCardTypeUsed(ctx, myCardType.Code)
+	if err != nil {
+		return customError.ErrModelGet(err, "Card")
+	}
+
+	if used {
+		return customError.ErrModelConflict("CardType")
+	}
+
+	return nil
+}
+
+func (u *UseCase) validateCode(ctx context.Context, code string, id int64) error {
+	if len(code) == 0 {
+		return customError.ErrRequestInvalidParam("code")
+	}
+
+	// the unique key on code also covers deleted card types
+	existed, err := u.CardTypeRepo.GetByCode(ctx, code, true)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return customError.ErrModelGet(err, "CardType")
+	}
+
+	if existed.ID != id {
+		return customError.ErrRequestInvalidParam("code")
+	}
+
+	return nil
+}
+
+func (u *UseCase) validateCreate(ctx context.Context, req *payload.CreateCardTypeRequest) error {
+	req.Code = strings.TrimSpace(req.Code)
+	if err := u.validateCode(ctx, req.Code, 0); err != nil {
+		return err
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if len(req.Name) == 0 {
+		return customError.ErrRequestInvalidParam("name")
+	}
+
+	req.Description = strings.TrimSpace(req.Description)
+	req.Icon = strings.TrimSpace(req.Icon)
+
+	return validateSchema(req.AttributesSchema)
+}
+
+// validateSchema only accepts documents which compile as a JSON Schema, existing cards are not checked again.
+func validateSchema(schema json.RawMessage) error {
+	if model.JSON(schema).IsNull() {
+		return nil
+	}
+
+	if _, err := teq.CompileJSONSchema(schema); err != nil {
+		return customError.ErrRequestInvalidParam("attributes_schema")
+	}
+
+	return nil
+}
+
+func (u *UseCase) Create(
+	ctx context.Context,
+	req *payload.CreateCardTypeRequest,
+) (*presenter.CardTypeResponseWrapper, error) {
+	if err := u.validateCreate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	myCardType := &model.CardType{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+		IsActive:    true,
+	}
+
+	if !model.JSON(req.AttributesSchema).IsNull() {
+		myCardType.AttributesSchema = model.JSON(req.AttributesSchema)
+	}
+
+	if req.IsActive != nil {
+		myCardType.IsActive = *req.IsActive
+	}
+
+	err := u.CardTypeRepo.Create(ctx, myCardType)
+	if err != nil {
+		return nil, customError.ErrModelCreate(err)
+	}
+
+	return &presenter.CardTypeResponseWrapper{CardType: myCardType}, nil
+}
+
+func (u *UseCase) validateUpdate(ctx context.Context, req *payload.UpdateCardTypeRequest) (*model.CardType, error) {
+	myCardType, err := u.CardTypeRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "CardType")
+	}
+
+	if req.Code != nil {
+		*req.Code = strings.TrimSpace(*req.Code)
+		if err = u.validateCode(ctx, *req.Code, myCardType.ID); err != nil {
+			return nil, err
+		}
+
+		if *req.Code != myCardType.Code {
+			if err = u.ensureUnused(ctx, myCardType); err != nil {
+				return nil, err
+			}
+		}
+
+		myCardType.Code = *req.Code
+	}
+
+	if req.Name != nil {
+		*req.Name = strings.TrimSpace(*req.Name)
+		if len(*req.Name) == 0 {
+			return nil, customError.ErrRequestInvalidParam("name")
+		}
+
+		myCardType.Name = *req.Name
+	}
+
+	if req.Description != nil {
+		myCardType.Description = strings.TrimSpace(*req.Description)
+	}
+
+	if req.Icon != nil {
+		myCardType.Icon = strings.TrimSpace(*req.Icon)
+	}
+
+	if req.IsActive != nil {
+		myCardType.IsActive = *req.IsActive
+	}
+
+	if req.AttributesSchema != nil {
+		if err = validateSchema(req.AttributesSchema); err != nil {
+			return nil, err
+		}
+
+		myCardType.AttributesSchema = nil
+		if !model.JSON(req.AttributesSchema).IsNull() {
+			myCardType.AttributesSchema = model.JSON(req.AttributesSchema)
+		}
+	}
+
+	return myCardType, nil
+}
+
+func (u *UseCase) Update(
+	ctx context.Context,
+	req *payload.UpdateCardTypeRequest,
+) (*presenter.CardTypeResponseWrapper, error) {
+	myCardType, err := u.validateUpdate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.CardTypeRepo.Update(ctx, myCardType)
+	if err != nil {
+		return nil, customError.ErrModelUpdate(err)
+	}
+
+	return &presenter.CardTypeResponseWrapper{CardType: myCardType}, nil
+}
+
+func (u *UseCase) Delete(ctx context.Context, req *payload.DeleteRequest) error {
+	myCardType, err := u.CardTypeRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrModelNotFound()
+		}
+
+		return customError.ErrModelGet(err, "CardType")
+	}
+
+	if err = u.ensureUnused(ctx, myCardType); err != nil {
+		return err
+	}
+
+	err = u.CardTypeRepo.Delete(ctx, myCardType, false)
+	if err != nil {
+		return customError.ErrModelDelete(err)
+	}
+
+	return nil
+}
+
+func (u *UseCase) getList(
+	ctx context.Context,
+	req *payload.GetListRequest,
+	conditions map[string]interface{},
+) (*presenter.ListCardTypeResponseWrapper, error) {
+	req.Format()
+
+	order := make([]string, 0)
+	if req.OrderBy != "" {
+		order = append(order, fmt.Sprintf("%s", req.OrderBy))
+	}
+
+	myCardTypes, total, err := u.CardTypeRepo.GetList(ctx, req.Search, req.Page, req.Limit, conditions, order)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "CardType")
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListCardTypeResponseWrapper{
+		CardTypes: myCardTypes,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
+
+func (u *UseCase) GetList(
+	ctx context.Context,
+	req *payload.GetListRequest,
+) (*presenter.ListCardTypeResponseWrapper, error) {
+	return u.getList(ctx, req, map[string]interface{}{})
+}
+
+func (u *UseCase) GetActiveList(
+	ctx context.Context,
+	req *payload.GetListRequest,
+) (*presenter.ListCardTypeResponseWrapper, error) {
+	return u.getList(ctx, req, map[string]interface{}{"is_active": true})
+}
+
+func (u *UseCase) GetByID(ctx context.Context, req *payload.GetByIDRequest) (*presenter.CardTypeResponseWrapper, error) {
+	myCardType, err := u.CardTypeRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "CardType")
+	}
+
+	return &presenter.CardTypeResponseWrapper{CardType: myCardType}, nil
+}
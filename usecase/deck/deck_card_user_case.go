@@ -0,0 +1,357 @@
+package deck
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"myapp/customError"
+	"myapp/fractional"
+	"myapp/model"
+	"myapp/mysql"
+	"myapp/payload"
+	"myapp/policy"
+	"myapp/presenter"
+)
+
+// maxPositionLength is where the deck gets renumbered, keys grow when cards keep landing on the same spot.
+const maxPositionLength = 128
+
+// canViewCard only lets users put cards they can see into a deck.
+func (u *UseCase) canViewCard(ctx context.Context, cardId int64) error {
+	myCard, err := u.CardRepo.GetByID(ctx, cardId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrRequestInvalidParam("card_id")
+		}
+
+		return customError.ErrModelGet(err, "Card")
+	}
+
+	sharedWith, err := u.CardAccess.SharedWith(ctx, myCard)
+	if err != nil {
+		return err
+	}
+
+	// a card the user can't see is as good as a card that doesn't exist
+	principal := policy.PrincipalFromContext(ctx)
+	if err = policy.Can(principal, policy.ActionView, policy.NewCardResource(myCard, sharedWith)); err != nil {
+		return customError.ErrRequestInvalidParam("card_id")
+	}
+
+	return nil
+}
+
+// neighbour returns the position of a card of the deck which is used as an anchor.
+func (u *UseCase) neighbour(ctx context.Context, deckId int64, cardId int64, param string) (string, error) {
+	myDeckCard, err := u.DeckCardRepo.Get(ctx, deckId, cardId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", customError.ErrRequestInvalidParam(param)
+		}
+
+		return "", customError.ErrModelGet(err, "DeckCard")
+	}
+
+	return myDeckCard.Position, nil
+}
+
+// position finds the key between the requested neighbours of cardId, without any neighbour the card goes last.
+// The deck must be locked so that two cards can't get the same key.
+func (u *UseCase) position(
+	ctx context.Context,
+	deckId int64,
+	cardId int64,
+	afterCardId int64,
+	beforeCardId int64,
+) (string, error) {
+	var (
+		low, high string
+		err       error
+	)
+
+	if afterCardId == cardId && cardId != 0 {
+		return "", customError.ErrRequestInvalidParam("after_card_id")
+	}
+
+	if beforeCardId == cardId && cardId != 0 {
+		return "", customError.ErrRequestInvalidParam("before_card_id")
+	}
+
+	if afterCardId != 0 {
+		if low, err = u.neighbour(ctx, deckId, afterCardId, "after_card_id"); err != nil {
+			return "", err
+		}
+	}
+
+	if beforeCardId != 0 {
+		if high, err = u.neighbour(ctx, deckId, beforeCardId, "before_card_id"); err != nil {
+			return "", err
+		}
+	}
+
+	// with a single neighbour the other bound is the card next to it
+	switch {
+	case afterCardId != 0 && beforeCardId != 0:
+		if low >= high {
+			return "", customError.ErrRequestInvalidParam("before_card_id")
+		}
+	case afterCardId != 0:
+		high, err = adjacentPosition(u.DeckCardRepo.GetNext(ctx, deckId, low, cardId))
+	case beforeCardId != 0:
+		low, err = adjacentPosition(u.DeckCardRepo.GetPrevious(ctx, deckId, high, cardId))
+	default:
+		low, err = adjacentPosition(u.DeckCardRepo.GetLast(ctx, deckId, cardId))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	key, err := fractional.KeyBetween(low, high)
+	if err != nil {
+		return "", customError.ErrModelConflict("DeckCard")
+	}
+
+	return key, nil
+}
+
+// adjacentPosition is empty when there is no card on that side.
+func adjacentPosition(myDeckCard *model.DeckCard, err error) (string, error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+
+		return "", customError.ErrModelGet(err, "DeckCard")
+	}
+
+	return myDeckCard.Position, nil
+}
+
+// rebalance spreads the keys of the deck again, it's the only change rewriting every card of the deck.
+func (u *UseCase) rebalance(ctx context.Context, deckId int64) error {
+	myDeckCards, err := u.DeckCardRepo.GetAllByDeck(ctx, deckId)
+	if err != nil {
+		return customError.ErrModelGet(err, "DeckCard")
+	}
+
+	keys := fractional.EvenKeys(len(myDeckCards))
+	for i := range myDeckCards {
+		if err = u.DeckCardRepo.UpdatePosition(ctx, &myDeckCards[i], keys[i]); err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+	}
+
+	return nil
+}
+
+// place computes the key of the card and renumbers the deck first when the key became too long.
+func (u *UseCase) place(
+	ctx context.Context,
+	deckId int64,
+	cardId int64,
+	afterCardId int64,
+	beforeCardId int64,
+) (string, error) {
+	key, err := u.position(ctx, deckId, cardId, afterCardId, beforeCardId)
+	if err != nil || len(key) <= maxPositionLength {
+		return key, err
+	}
+
+	if err = u.rebalance(ctx, deckId); err != nil {
+		return "", err
+	}
+
+	return u.position(ctx, deckId, cardId, afterCardId, beforeCardId)
+}
+
+// hideCards drops the content of the cards the caller can't view, sharing a deck doesn't share its cards.
+// Such cards keep their place in the deck with their id and position only.
+func (u *UseCase) hideCards(ctx context.Context, myDeckCards []model.DeckCard) error {
+	myCards := make([]*model.Card, 0, len(myDeckCards))
+	for i := range myDeckCards {
+		if myDeckCards[i].Card != nil {
+			myCards = append(myCards, myDeckCards[i].Card)
+		}
+	}
+
+	viewable, err := u.CardAccess.Viewable(ctx, myCards)
+	if err != nil {
+		return err
+	}
+
+	for i := range myDeckCards {
+		if !viewable[myDeckCards[i].CardId] {
+			myDeckCards[i].Card = nil
+		}
+	}
+
+	return nil
+}
+
+func (u *UseCase) GetCards(
+	ctx context.Context,
+	req *payload.GetListDeckCardRequest,
+) (*presenter.ListDeckCardResponseWrapper, error) {
+	req.Format()
+
+	myDeck, err := u.getDeckWithPermission(ctx, req.DeckId, req.UserId, model.CardPermissionViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	myDeckCards, total, err := u.DeckCardRepo.GetList(ctx, myDeck.ID, req.Page, req.Limit)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "DeckCard")
+	}
+
+	if err = u.hideCards(ctx, myDeckCards); err != nil {
+		return nil, err
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListDeckCardResponseWrapper{
+		Cards: myDeckCards,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
+
+func (u *UseCase) AddCard(
+	ctx context.Context,
+	req *payload.AddDeckCardRequest,
+) (*presenter.DeckCardResponseWrapper, error) {
+	myDeck, err := u.getDeckWithPermission(ctx, req.DeckId, req.UserId, model.CardPermissionEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CardId == 0 {
+		return nil, customError.ErrRequestInvalidParam("card_id")
+	}
+
+	if err = u.canViewCard(ctx, req.CardId); err != nil {
+		return nil, err
+	}
+
+	var myDeckCard *model.DeckCard
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err = mysql.TxEnd(ctx, func(ctx context.Context) error {
+		if _, err := u.DeckRepo.GetByIDForUpdate(ctx, myDeck.ID); err != nil {
+			return customError.ErrModelGet(err, "Deck")
+		}
+
+		_, err := u.DeckCardRepo.Get(ctx, myDeck.ID, req.CardId)
+		if err == nil {
+			return customError.ErrModelConflict("DeckCard")
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrModelGet(err, "DeckCard")
+		}
+
+		key, err := u.place(ctx, myDeck.ID, req.CardId, req.AfterCardId, req.BeforeCardId)
+		if err != nil {
+			return err
+		}
+
+		myDeckCard = &model.DeckCard{
+			DeckId:   myDeck.ID,
+			CardId:   req.CardId,
+			Position: key,
+		}
+
+		err = u.DeckCardRepo.Create(ctx, myDeckCard)
+		if err != nil {
+			return customError.ErrModelCreate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.DeckCardResponseWrapper{DeckCard: myDeckCard}, nil
+}
+
+// MoveCard only rewrites the position of the moved card.
+func (u *UseCase) MoveCard(
+	ctx context.Context,
+	req *payload.MoveDeckCardRequest,
+) (*presenter.DeckCardResponseWrapper, error) {
+	if req.AfterCardId == 0 && req.BeforeCardId == 0 {
+		return nil, customError.ErrRequestInvalidParam("after_card_id")
+	}
+
+	myDeck, err := u.getDeckWithPermission(ctx, req.DeckId, req.UserId, model.CardPermissionEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	var myDeckCard *model.DeckCard
+
+	ctx = mysql.TxBegin(ctx, u.GetClient)
+	_, err = mysql.TxEnd(ctx, func(ctx context.Context) error {
+		if _, err := u.DeckRepo.GetByIDForUpdate(ctx, myDeck.ID); err != nil {
+			return customError.ErrModelGet(err, "Deck")
+		}
+
+		var err error
+
+		myDeckCard, err = u.DeckCardRepo.Get(ctx, myDeck.ID, req.CardId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return customError.ErrModelNotFound()
+			}
+
+			return customError.ErrModelGet(err, "DeckCard")
+		}
+
+		key, err := u.place(ctx, myDeck.ID, req.CardId, req.AfterCardId, req.BeforeCardId)
+		if err != nil {
+			return err
+		}
+
+		err = u.DeckCardRepo.UpdatePosition(ctx, myDeckCard, key)
+		if err != nil {
+			return customError.ErrModelUpdate(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.DeckCardResponseWrapper{DeckCard: myDeckCard}, nil
+}
+
+func (u *UseCase) RemoveCard(ctx context.Context, req *payload.RemoveDeckCardRequest) error {
+	myDeck, err := u.getDeckWithPermission(ctx, req.DeckId, req.UserId, model.CardPermissionEditor)
+	if err != nil {
+		return err
+	}
+
+	myDeckCard, err := u.DeckCardRepo.Get(ctx, myDeck.ID, req.CardId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrModelNotFound()
+		}
+
+		return customError.ErrModelGet(err, "DeckCard")
+	}
+
+	err = u.DeckCardRepo.Delete(ctx, myDeckCard)
+	if err != nil {
+		return customError.ErrModelDelete(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/payload"
+	"myapp/presenter"
+)
+
+func (u *UseCase) validateShare(ctx context.Context, req *payload.ShareDeckRequest) error {
+	req.Permission = strings.ToLower(strings.TrimSpace(req.Permission))
+	if req.Permission == "" {
+		req.Permission = model.CardPermissionViewer
+	}
+
+	if !model.IsValidSharePermission(req.Permission) {
+		return customError.ErrRequestInvalidParam("permission")
+	}
+
+	if req.TargetUserId == 0 || req.TargetUserId == req.UserId {
+		return customError.ErrRequestInvalidParam("user_id")
+	}
+
+	_, err := u.UserRepo.GetByID(ctx, req.TargetUserId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrRequestInvalidParam("user_id")
+		}
+
+		return customError.ErrModelGet(err, "User")
+	}
+
+	return nil
+}
+
+// Share grants access to the deck and the order of its cards, the cards themselves are not shared.
+func (u *UseCase) Share(
+	ctx context.Context,
+	req *payload.ShareDeckRequest,
+) (*presenter.DeckShareResponseWrapper, error) {
+	myDeck, err := u.getDeckWithPermission(ctx, req.DeckId, req.UserId, model.CardPermissionOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.validateShare(ctx, req); err != nil {
+		return nil, err
+	}
+
+	myShare, err := u.DeckShareRepo.GetByDeckAndUser(ctx, myDeck.ID, req.TargetUserId)
+	switch {
+	case err == nil:
+		myShare.Permission = req.Permission
+
+		err = u.DeckShareRepo.Update(ctx, myShare)
+		if err != nil {
+			return nil, customError.ErrModelUpdate(err)
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		myShare = &model.DeckShare{
+			DeckId:     myDeck.ID,
+			UserId:     req.TargetUserId,
+			Permission: req.Permission,
+		}
+
+		err = u.DeckShareRepo.Create(ctx, myShare)
+		if err != nil {
+			return nil, customError.ErrModelCreate(err)
+		}
+	default:
+		return nil, customError.ErrModelGet(err, "DeckShare")
+	}
+
+	return &presenter.DeckShareResponseWrapper{Share: myShare}, nil
+}
+
+func (u *UseCase) GetShares(
+	ctx context.Context,
+	req *payload.GetListDeckShareRequest,
+) (*presenter.ListDeckShareResponseWrapper, error) {
+	myDeck, err := u.getDeckWithPermission(ctx, req.DeckId, req.UserId, model.CardPermissionOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	myShares, err := u.DeckShareRepo.GetListByDeck(ctx, myDeck.ID)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "DeckShare")
+	}
+
+	return &presenter.ListDeckShareResponseWrapper{Shares: myShares}, nil
+}
+
+// RevokeShare removes a share, either by the owner of the deck or by the collaborator leaving it.
+func (u *UseCase) RevokeShare(ctx context.Context, req *payload.RevokeDeckShareRequest) error {
+	if req.TargetUserId != req.UserId {
+		_, err := u.getDeckWithPermission(ctx, req.DeckId, req.UserId, model.CardPermissionOwner)
+		if err != nil {
+			return err
+		}
+	}
+
+	myShare, err := u.DeckShareRepo.GetByDeckAndUser(ctx, req.DeckId, req.TargetUserId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return customError.ErrModelNotFound()
+		}
+
+		return customError.ErrModelGet(err, "DeckShare")
+	}
+
+	err = u.DeckShareRepo.Delete(ctx, myShare)
+	if err != nil {
+		return customError.ErrModelDelete(err)
+	}
+
+	return nil
+}
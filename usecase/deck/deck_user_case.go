@@ -0,0 +1,228 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/repository"
+	"myapp/repository/card"
+	"myapp/repository/deck"
+	"myapp/repository/deckCard"
+	"myapp/repository/deckShare"
+	"myapp/repository/user"
+	"myapp/usecase/cardAccess"
+)
+
+type DeckUseCase interface {
+	Create(ctx context.Context, req *payload.CreateDeckRequest) (*presenter.DeckResponseWrapper, error)
+	Update(ctx context.Context, req *payload.UpdateDeckRequest) (*presenter.DeckResponseWrapper, error)
+	GetByID(ctx context.Context, req *payload.GetByIDRequest) (*presenter.DeckResponseWrapper, error)
+	GetList(ctx context.Context, req *payload.GetListDeckRequest) (*presenter.ListDeckResponseWrapper, error)
+	Delete(ctx context.Context, req *payload.DeleteRequest) error
+	GetCards(ctx context.Context, req *payload.GetListDeckCardRequest) (*presenter.ListDeckCardResponseWrapper, error)
+	AddCard(ctx context.Context, req *payload.AddDeckCardRequest) (*presenter.DeckCardResponseWrapper, error)
+	MoveCard(ctx context.Context, req *payload.MoveDeckCardRequest) (*presenter.DeckCardResponseWrapper, error)
+	RemoveCard(ctx context.Context, req *payload.RemoveDeckCardRequest) error
+	Share(ctx context.Context, req *payload.ShareDeckRequest) (*presenter.DeckShareResponseWrapper, error)
+	GetShares(ctx context.Context, req *payload.GetListDeckShareRequest) (*presenter.ListDeckShareResponseWrapper, error)
+	RevokeShare(ctx context.Context, req *payload.RevokeDeckShareRequest) error
+}
+
+type UseCase struct {
+	GetClient     func(ctx context.Context) *gorm.DB
+	DeckRepo      deck.Repository
+	DeckCardRepo  deckCard.Repository
+	DeckShareRepo deckShare.Repository
+	CardRepo      card.Repository
+	UserRepo      user.Repository
+	CardAccess    *cardAccess.Access
+}
+
+func New(repo *repository.Repository) DeckUseCase {
+	return &UseCase{
+		GetClient:     repo.GetClient,
+		DeckRepo:      repo.Deck,
+		DeckCardRepo:  repo.DeckCard,
+		DeckShareRepo: repo.DeckShare,
+		CardRepo:      repo.Card,
+		UserRepo:      repo.User,
+		CardAccess:    cardAccess.New(repo),
+	}
+}
+
+func userIdFromContext(ctx context.Context) int64 {
+	userId, _ := ctx.Value("user_id").(int64)
+
+	return userId
+}
+
+// getDeckWithPermission loads a deck the user holds at least the required permission on,
+// either as its owner or through a share. Decks the user can't see at all are reported as not found.
+func (u *UseCase) getDeckWithPermission(
+	ctx context.Context,
+	id int64,
+	userId int64,
+	required string,
+) (*model.Deck, error) {
+	myDeck, err := u.DeckRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, customError.ErrModelNotFound()
+		}
+
+		return nil, customError.ErrModelGet(err, "Deck")
+	}
+
+	granted := model.CardPermissionOwner
+	if myDeck.UserId != userId {
+		share, err := u.DeckShareRepo.GetByDeckAndUser(ctx, myDeck.ID, userId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, customError.ErrModelNotFound()
+			}
+
+			return nil, customError.ErrModelGet(err, "DeckShare")
+		}
+
+		granted = share.Permission
+	}
+
+	if !model.HasCardPermission(granted, required) {
+		return nil, customError.ErrNoPermission()
+	}
+
+	return myDeck, nil
+}
+
+func (u *UseCase) Create(
+	ctx context.Context,
+	req *payload.CreateDeckRequest,
+) (*presenter.DeckResponseWrapper, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if len(req.Name) == 0 {
+		return nil, customError.ErrRequestInvalidParam("name")
+	}
+
+	myDeck := &model.Deck{
+		UserId:      req.UserId,
+		Name:        req.Name,
+		Description: strings.TrimSpace(req.Description),
+	}
+
+	err := u.DeckRepo.Create(ctx, myDeck)
+	if err != nil {
+		return nil, customError.ErrModelCreate(err)
+	}
+
+	return &presenter.DeckResponseWrapper{Deck: myDeck}, nil
+}
+
+func (u *UseCase) Update(
+	ctx context.Context,
+	req *payload.UpdateDeckRequest,
+) (*presenter.DeckResponseWrapper, error) {
+	myDeck, err := u.getDeckWithPermission(ctx, req.ID, req.UserId, model.CardPermissionEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		*req.Name = strings.TrimSpace(*req.Name)
+		if len(*req.Name) == 0 {
+			return nil, customError.ErrRequestInvalidParam("name")
+		}
+
+		myDeck.Name = *req.Name
+	}
+
+	if req.Description != nil {
+		myDeck.Description = strings.TrimSpace(*req.Description)
+	}
+
+	err = u.DeckRepo.Update(ctx, myDeck)
+	if err != nil {
+		return nil, customError.ErrModelUpdate(err)
+	}
+
+	return &presenter.DeckResponseWrapper{Deck: myDeck}, nil
+}
+
+func (u *UseCase) GetByID(ctx context.Context, req *payload.GetByIDRequest) (*presenter.DeckResponseWrapper, error) {
+	myDeck, err := u.getDeckWithPermission(ctx, req.ID, userIdFromContext(ctx), model.CardPermissionViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &presenter.DeckResponseWrapper{Deck: myDeck}, nil
+}
+
+func (u *UseCase) GetList(
+	ctx context.Context,
+	req *payload.GetListDeckRequest,
+) (*presenter.ListDeckResponseWrapper, error) {
+	req.Format()
+
+	var (
+		order     = make([]string, 0)
+		condition clause.Expression
+		owned     = clause.Eq{Column: "user_id", Value: req.UserId}
+		shared    = clause.Expr{
+			SQL:  "id IN (SELECT deck_id FROM deck_shares WHERE user_id = ?)",
+			Vars: []interface{}{req.UserId},
+		}
+	)
+
+	if req.OrderBy != "" {
+		order = append(order, fmt.Sprintf("%s", req.OrderBy))
+	}
+
+	switch strings.ToLower(strings.TrimSpace(req.Scope)) {
+	case "", payload.CardScopeOwned:
+		condition = owned
+	case payload.CardScopeShared:
+		condition = shared
+	case payload.CardScopeAll:
+		condition = clause.Or(owned, shared)
+	default:
+		return nil, customError.ErrRequestInvalidParam("scope")
+	}
+
+	myDecks, total, err := u.DeckRepo.GetList(ctx, req.Search, req.Page, req.Limit, condition, order)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "Deck")
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListDeckResponseWrapper{
+		Decks: myDecks,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
+
+func (u *UseCase) Delete(ctx context.Context, req *payload.DeleteRequest) error {
+	myDeck, err := u.getDeckWithPermission(ctx, req.ID, userIdFromContext(ctx), model.CardPermissionOwner)
+	if err != nil {
+		return err
+	}
+
+	err = u.DeckRepo.Delete(ctx, myDeck)
+	if err != nil {
+		return customError.ErrModelDelete(err)
+	}
+
+	return nil
+}
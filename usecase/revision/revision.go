@@ -0,0 +1,100 @@
+package revision
+
+import (
+	"context"
+	"encoding/json"
+
+	"gorm.io/gorm"
+	"myapp/config"
+	"myapp/customError"
+	"myapp/model"
+	"myapp/mysql"
+	"myapp/repository"
+	"myapp/repository/card"
+	"myapp/repository/cardRevision"
+)
+
+// Recorder keeps the revisions of cards, every use case writing a card goes through it.
+type Recorder struct {
+	GetClient        func(ctx context.Context) *gorm.DB
+	CardRepo         card.Repository
+	CardRevisionRepo cardRevision.Repository
+}
+
+func New(repo *repository.Repository) *Recorder {
+	return &Recorder{
+		GetClient:        repo.GetClient,
+		CardRepo:         repo.Card,
+		CardRevisionRepo: repo.CardRevision,
+	}
+}
+
+// Write runs write and records the revision it makes in one transaction. The card stays locked
+// meanwhile, so concurrent writes number their revisions one after the other, a new card has no one to wait for.
+// It joins the transaction of ctx when there is one.
+func (r *Recorder) Write(
+	ctx context.Context,
+	myCard *model.Card,
+	action string,
+	actorId int64,
+	write func(ctx context.Context) error,
+) error {
+	fn := func(ctx context.Context) error {
+		if action != model.CardRevisionActionCreate {
+			if err := r.CardRepo.LockByID(ctx, myCard.ID); err != nil {
+				return customError.ErrModelGet(err, "Card")
+			}
+		}
+
+		if err := write(ctx); err != nil {
+			return err
+		}
+
+		return r.record(ctx, myCard, action, actorId)
+	}
+
+	if mysql.IsEnableTx(ctx) {
+		return fn(ctx)
+	}
+
+	ctx = mysql.TxBegin(ctx, r.GetClient)
+	_, err := mysql.TxEnd(ctx, fn)
+
+	return err
+}
+
+// record stores a snapshot of the card and drops the revisions beyond the configured retention.
+func (r *Recorder) record(ctx context.Context, myCard *model.Card, action string, actorId int64) error {
+	snapshot, err := json.Marshal(model.SnapshotOf(myCard))
+	if err != nil {
+		return customError.ErrModelCreate(err)
+	}
+
+	latest, err := r.CardRevisionRepo.GetLatestRevision(ctx, myCard.ID)
+	if err != nil {
+		return customError.ErrModelGet(err, "CardRevision")
+	}
+
+	myRevision := &model.CardRevision{
+		CardId:   myCard.ID,
+		Revision: latest + 1,
+		Action:   action,
+		ActorId:  actorId,
+		Snapshot: snapshot,
+	}
+
+	err = r.CardRevisionRepo.Create(ctx, myRevision)
+	if err != nil {
+		return customError.ErrModelCreate(err)
+	}
+
+	retention := config.GetConfig().Card.RevisionRetention
+	if retention > 0 && myRevision.Revision > retention {
+		err = r.CardRevisionRepo.DeleteOlderThan(ctx, myCard.ID, myRevision.Revision-retention+1)
+		if err != nil {
+			return customError.ErrModelDelete(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package tag
+
+import (
+	"context"
+
+	"myapp/customError"
+	"myapp/payload"
+	"myapp/presenter"
+	"myapp/repository"
+	"myapp/repository/tag"
+)
+
+type TagUseCase interface {
+	GetList(ctx context.Context, req *payload.GetListTagRequest) (*presenter.ListTagResponseWrapper, error)
+}
+
+type UseCase struct {
+	TagRepo tag.Repository
+}
+
+func New(repo *repository.Repository) TagUseCase {
+	return &UseCase{
+		TagRepo: repo.Tag,
+	}
+}
+
+func (u *UseCase) GetList(
+	ctx context.Context,
+	req *payload.GetListTagRequest,
+) (*presenter.ListTagResponseWrapper, error) {
+	req.Format()
+
+	myTags, total, err := u.TagRepo.GetListWithUsage(ctx, req.UserId, req.Search, req.Page, req.Limit)
+	if err != nil {
+		return nil, customError.ErrModelGet(err, "Tag")
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	return &presenter.ListTagResponseWrapper{
+		Tags: myTags,
+		Meta: map[string]interface{}{
+			"page":  req.Page,
+			"limit": req.Limit,
+			"total": total,
+		},
+	}, nil
+}
@@ -3,17 +3,38 @@ package usecase
 import (
 	"myapp/repository"
 	"myapp/usecase/card"
+	"myapp/usecase/cardListing"
+	"myapp/usecase/cardShare"
+	"myapp/usecase/cardStat"
+	"myapp/usecase/cardTransfer"
+	"myapp/usecase/cardType"
+	"myapp/usecase/deck"
+	"myapp/usecase/tag"
 	"myapp/usecase/user"
 )
 
 type UseCase struct {
-	User user.UserUserCase
-	Card card.CardUseCase
+	User         user.UserUserCase
+	Card         card.CardUseCase
+	CardType     cardType.CardTypeUseCase
+	CardTransfer cardTransfer.CardTransferUseCase
+	CardListing  cardListing.CardListingUseCase
+	CardShare    cardShare.CardShareUseCase
+	CardStat     cardStat.CardStatUseCase
+	Tag          tag.TagUseCase
+	Deck         deck.DeckUseCase
 }
 
 func New(repo *repository.Repository) *UseCase {
 	return &UseCase{
-		User: user.New(repo),
-		Card: card.New(repo),
+		User:         user.New(repo),
+		Card:         card.New(repo),
+		CardType:     cardType.New(repo),
+		CardTransfer: cardTransfer.New(repo),
+		CardListing:  cardListing.New(repo),
+		CardShare:    cardShare.New(repo),
+		CardStat:     cardStat.New(repo),
+		Tag:          tag.New(repo),
+		Deck:         deck.New(repo),
 	}
 }
@@ -10,10 +10,15 @@ import (
 	"myapp/auth"
 	"myapp/customError"
 	"myapp/model"
+	"myapp/pagination"
 	"myapp/payload"
+	"myapp/policy"
 	"myapp/presenter"
+	"myapp/query"
 	"myapp/repository"
+	"myapp/repository/base"
 	"myapp/repository/user"
+	"myapp/validation"
 	"strings"
 )
 
@@ -37,39 +42,13 @@ func New(repo *repository.Repository) UserUserCase {
 }
 
 func (u *UseCase) validateCreate(req *payload.CreateUserRequest) error {
-	if req.Name == "" {
-		return customError.ErrRequestInvalidParam("name")
-	}
-
-	if req.Email == "" {
-		return customError.ErrRequestInvalidParam("Email")
-	}
-
-	if req.Username == "" {
-		return customError.ErrRequestInvalidParam("UserName")
-	}
-
-	if req.Password == "" {
-		return customError.ErrRequestInvalidParam("Password")
+	if err := validation.Validate(req); err != nil {
+		return err
 	}
 
 	req.Name = strings.TrimSpace(req.Name)
-	if len(req.Name) == 0 {
-		req.Name = ""
-		return customError.ErrRequestInvalidParam("name")
-	}
-
-	if len(req.Email) == 0 {
-		return customError.ErrRequestInvalidParam("email")
-	}
-
-	if len(req.Username) == 0 {
-		return customError.ErrRequestInvalidParam("username")
-	}
-
-	if len(req.Password) == 0 {
-		return customError.ErrRequestInvalidParam("password")
-	}
+	req.Email = strings.TrimSpace(req.Email)
+	req.Username = strings.TrimSpace(req.Username)
 
 	return nil
 }
@@ -88,7 +67,6 @@ func (u *UseCase) Create(
 		Name:     req.Name,
 		Username: req.Username,
 		Email:    req.Email,
-		Score:    req.Score,
 		Password: string(Password),
 	}
 
@@ -140,35 +118,28 @@ func (u *UseCase) validateUpdate(ctx context.Context, req *payload.UpdateUserReq
 		return nil, customError.ErrModelGet(err, "User")
 	}
 
-	if req.Name != nil {
-		*req.Name = strings.TrimSpace(*req.Name)
-		if len(*req.Name) == 0 {
-			return nil, customError.ErrRequestInvalidParam("name")
-		}
-
-		myUser.Name = *req.Name
+	if err = policy.Can(policy.PrincipalFromContext(ctx), policy.ActionUpdate, policy.NewUserResource(myUser)); err != nil {
+		return nil, err
 	}
 
-	if req.Username != nil {
-		*req.Username = strings.TrimSpace(*req.Username)
-		if len(*req.Username) == 0 {
-			return nil, customError.ErrRequestInvalidParam("Username")
-		}
+	if req.Version != 0 && req.Version != myUser.Version {
+		return nil, customError.ErrPreconditionFailed()
+	}
 
-		myUser.Username = *req.Username
+	if err = validation.Validate(req); err != nil {
+		return nil, err
 	}
 
-	if req.Email != nil {
-		*req.Email = strings.TrimSpace(*req.Email)
-		if len(*req.Email) == 0 {
-			return nil, customError.ErrRequestInvalidParam("Email")
-		}
+	if req.Name != nil {
+		myUser.Name = strings.TrimSpace(*req.Name)
+	}
 
-		myUser.Email = *req.Email
+	if req.Username != nil {
+		myUser.Username = strings.TrimSpace(*req.Username)
 	}
 
-	if req.Score != nil {
-		myUser.Score = *req.Score
+	if req.Email != nil {
+		myUser.Email = strings.TrimSpace(*req.Email)
 	}
 
 	return myUser, nil
@@ -185,6 +156,10 @@ func (u *UseCase) Update(
 
 	err = u.UserRepo.Update(ctx, myUser)
 	if err != nil {
+		if errors.Is(err, base.ErrStale) {
+			return nil, customError.ErrPreconditionFailed()
+		}
+
 		return nil, customError.ErrModelUpdate(err)
 	}
 
@@ -201,6 +176,10 @@ func (u *UseCase) Delete(ctx context.Context, req *payload.DeleteRequest) error
 		return customError.ErrModelGet(err, "User")
 	}
 
+	if err = policy.Can(policy.PrincipalFromContext(ctx), policy.ActionDelete, policy.NewUserResource(myUser)); err != nil {
+		return err
+	}
+
 	err = u.UserRepo.Delete(ctx, myUser, false)
 	if err != nil {
 		return customError.ErrModelDelete(err)
@@ -215,16 +194,31 @@ func (u *UseCase) GetList(
 ) (*presenter.ListUserResponseWrapper, error) {
 	req.Format()
 
-	var (
-		order      = make([]string, 0)
-		conditions = map[string]interface{}{}
-	)
+	if req.Sort == "" {
+		req.Sort = req.OrderBy
+	}
+
+	keys, err := query.Users.ParseSort(req.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	keyset, err := pagination.NewKeyset(keys, req.Cursor, req.Page, req.Limit)
+	if err != nil {
+		return nil, customError.ErrRequestInvalidParam("cursor")
+	}
+
+	conditions, err := query.Users.ParseFilters(req.Filters)
+	if err != nil {
+		return nil, err
+	}
 
-	if req.OrderBy != "" {
-		order = append(order, fmt.Sprintf("%s", req.OrderBy))
+	selection, err := query.Users.ParseSelection(req.Fields, req.Include, req.IncludeSet)
+	if err != nil {
+		return nil, err
 	}
 
-	myUsers, total, err := u.UserRepo.GetList(ctx, req.Search, req.Page, req.Limit, conditions, order)
+	myUsers, total, page, err := u.UserRepo.GetList(ctx, req.Search, conditions, keyset, req.SkipCount, selection)
 	if err != nil {
 		return nil, customError.ErrModelGet(err, "User")
 	}
@@ -233,12 +227,9 @@ func (u *UseCase) GetList(
 		req.Page = 1
 	}
 	return &presenter.ListUserResponseWrapper{
-		Users: myUsers,
-		Meta: map[string]interface{}{
-			"page":  req.Page,
-			"limit": req.Limit,
-			"total": total,
-		},
+		Users:  myUsers,
+		Meta:   pagination.Meta(req.Page, req.Limit, total, page),
+		Fields: selection.Keys(),
 	}, nil
 }
 
@@ -252,5 +243,9 @@ func (u *UseCase) GetByID(ctx context.Context, req *payload.GetByIDRequest) (*pr
 		return nil, customError.ErrModelGet(err, "User")
 	}
 
+	if err = policy.Can(policy.PrincipalFromContext(ctx), policy.ActionView, policy.NewUserResource(myUser)); err != nil {
+		return nil, err
+	}
+
 	return &presenter.UserResponseWrapper{User: myUser}, nil
 }
@@ -0,0 +1,25 @@
+package validation
+
+import (
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Binder validates every payload once echo bound it, handlers get the failed validations from c.Bind.
+type Binder struct {
+	echo.DefaultBinder
+}
+
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	// a payload with a broken tag is a bug, refused the first time a type is bound whatever the request holds
+	if err := Check(reflect.TypeOf(i)); err != nil {
+		panic(err)
+	}
+
+	if err := b.DefaultBinder.Bind(i, c); err != nil {
+		return err
+	}
+
+	return Validate(i)
+}
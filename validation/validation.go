@@ -0,0 +1,408 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"myapp/appError"
+	"myapp/customError"
+	"myapp/teq"
+)
+
+// Rule reports whether value passes the rule with param, parent is the struct holding the field.
+// Rules only see the fields which are set, required is the one rule looking at empty fields.
+type Rule func(value reflect.Value, param string, parent reflect.Value) bool
+
+// Constraint is one rule of a `validate` tag, `max=255` is the rule max with the param 255.
+type Constraint struct {
+	Rule  string
+	Param string
+}
+
+type rule struct {
+	check Rule
+	// message is formatted with the param
+	message func(value reflect.Value, param string) string
+}
+
+var (
+	mu    sync.RWMutex
+	rules = map[string]rule{
+		"notblank": {notBlank, fixed("must not be blank")},
+		"min":      {minimum, bound("at least")},
+		"max":      {maximum, bound("at most")},
+		"email":    {email, fixed("must be an email address")},
+		"oneof": {oneOf, func(_ reflect.Value, param string) string {
+			return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+		}},
+		"required_without": {
+			func(reflect.Value, string, reflect.Value) bool { return true },
+			func(_ reflect.Value, param string) string { return fmt.Sprintf("is required without `%s`", param) },
+		},
+	}
+	timeType = reflect.TypeOf(time.Time{})
+	// checked holds the result of Check for every type it has seen
+	checked sync.Map
+)
+
+// Register adds a custom rule, message is formatted with the param of the tag, `must be a %s` for `kind=color`.
+func Register(name string, check Rule, message string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	rules[name] = rule{check, func(_ reflect.Value, param string) string {
+		if strings.Contains(message, "%s") {
+			return fmt.Sprintf(message, param)
+		}
+
+		return message
+	}}
+
+	// the types refused for naming the rule are checked again
+	checked.Range(func(t, _ interface{}) bool {
+		checked.Delete(t)
+
+		return true
+	})
+}
+
+// Constraints parses the `validate` tag of field, `validate:"required,max=255"`.
+func Constraints(field reflect.StructField) []Constraint {
+	tag := field.Tag.Get("validate")
+	if tag == "" {
+		return nil
+	}
+
+	var constraints []Constraint
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		constraints = append(constraints, Constraint{Rule: name, Param: param})
+	}
+
+	return constraints
+}
+
+// Validate checks the `validate` tags of the struct v points to, nested structs and slices of structs included.
+// Every invalid field is listed in the single error it returns.
+func Validate(v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if err := Check(value.Type()); err != nil {
+		panic(err)
+	}
+
+	fields := validateStruct(value, "")
+	if len(fields) > 0 {
+		return customError.ErrInvalidFields(fields)
+	}
+
+	return nil
+}
+
+func validateStruct(value reflect.Value, prefix string) []appError.FieldError {
+	var (
+		t      = value.Type()
+		fields []appError.FieldError
+	)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, validateStruct(value.Field(i), prefix)...)
+			continue
+		}
+
+		name := prefix + FieldName(field)
+		fields = append(fields, validateField(value.Field(i), name, Constraints(field), value)...)
+		fields = append(fields, dive(value.Field(i), name)...)
+	}
+
+	return fields
+}
+
+func validateField(value reflect.Value, name string, constraints []Constraint, parent reflect.Value) []appError.FieldError {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	set := !value.IsZero()
+
+	for _, c := range constraints {
+		switch c.Rule {
+		case "required":
+			if !set || !notBlank(value, "", parent) {
+				return []appError.FieldError{{Field: name, Code: c.Rule, Message: "is required"}}
+			}
+		case "required_without":
+			other := fieldByName(parent, c.Param)
+			if (!set || !notBlank(value, "", parent)) && (!other.IsValid() || other.IsZero()) {
+				return []appError.FieldError{{Field: name, Code: c.Rule, Message: rules[c.Rule].message(value, c.Param)}}
+			}
+		}
+	}
+
+	if !set {
+		return nil
+	}
+
+	for _, c := range constraints {
+		if c.Rule == "required" || c.Rule == "required_without" {
+			continue
+		}
+
+		// Check refused the types with an unknown rule
+		r := rules[c.Rule]
+		if !r.check(indirect(value), c.Param, parent) {
+			// one error per field, the first rule it breaks
+			return []appError.FieldError{{Field: name, Code: c.Rule, Message: r.message(indirect(value), c.Param)}}
+		}
+	}
+
+	return nil
+}
+
+// dive validates the structs held by a field, directly, through a pointer or in a slice.
+func dive(value reflect.Value, name string) []appError.FieldError {
+	value = indirect(value)
+
+	switch {
+	case value.Kind() == reflect.Struct && value.Type() != timeType:
+		return validateStruct(value, name+".")
+	case value.Kind() == reflect.Slice:
+		var fields []appError.FieldError
+		for i := 0; i < value.Len(); i++ {
+			item := indirect(value.Index(i))
+			if item.Kind() == reflect.Struct && item.Type() != timeType {
+				fields = append(fields, validateStruct(item, fmt.Sprintf("%s[%d].", name, i))...)
+			}
+		}
+
+		return fields
+	}
+
+	return nil
+}
+
+// FieldName is the name clients know the field by, from its json tag, else its query or form tag.
+func FieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query", "form"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+func fieldByName(parent reflect.Value, name string) reflect.Value {
+	t := parent.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if FieldName(t.Field(i)) == name {
+			return parent.Field(i)
+		}
+	}
+
+	return reflect.Value{}
+}
+
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	return value
+}
+
+func fixed(message string) func(reflect.Value, string) string {
+	return func(reflect.Value, string) string {
+		return message
+	}
+}
+
+// bound words the min and max messages after the kind of value.
+func bound(word string) func(reflect.Value, string) string {
+	return func(value reflect.Value, param string) string {
+		switch value.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be %s %s characters long", word, param)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must have %s %s items", word, param)
+		}
+
+		return fmt.Sprintf("must be %s %s", word, param)
+	}
+}
+
+func notBlank(value reflect.Value, _ string, _ reflect.Value) bool {
+	value = indirect(value)
+	if value.Kind() == reflect.String {
+		return strings.TrimSpace(value.String()) != ""
+	}
+
+	return !value.IsZero()
+}
+
+func email(value reflect.Value, _ string, _ reflect.Value) bool {
+	ok, err := teq.IsEmail(strings.TrimSpace(value.String()))
+
+	return err == nil && ok
+}
+
+func oneOf(value reflect.Value, param string, _ reflect.Value) bool {
+	for _, allowed := range strings.Fields(param) {
+		if fmt.Sprint(value.Interface()) == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func minimum(value reflect.Value, param string, _ reflect.Value) bool {
+	size, limit, ok := measure(value, param)
+
+	return !ok || size >= limit
+}
+
+func maximum(value reflect.Value, param string, _ reflect.Value) bool {
+	size, limit, ok := measure(value, param)
+
+	return !ok || size <= limit
+}
+
+// measure returns the length of strings and slices or the number itself, compared with the limit in param.
+func measure(value reflect.Value, param string) (float64, float64, bool) {
+	var limit float64
+	if _, err := fmt.Sscan(param, &limit); err != nil {
+		return 0, 0, false
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(strings.TrimSpace(value.String()))), limit, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), limit, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), limit, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), limit, true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), limit, true
+	}
+
+	return 0, 0, false
+}
+
+// Check reports the `validate` tags of the struct t, or one it holds, which name a rule nobody registered
+// or a required_without field the struct lacks. The result is kept, a type is only walked once.
+func Check(t reflect.Type) error {
+	if err, ok := checked.Load(t); ok {
+		err, _ := err.(error)
+
+		return err
+	}
+
+	err := check(t, make(map[reflect.Type]bool))
+	checked.Store(t, err)
+
+	return err
+}
+
+func check(t reflect.Type, seen map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || t == timeType || seen[t] {
+		return nil
+	}
+
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		for _, c := range Constraints(field) {
+			if !known(c.Rule) {
+				return fmt.Errorf("validation: unknown rule %q on %s.%s", c.Rule, t, field.Name)
+			}
+
+			if c.Rule == "required_without" && !hasField(t, c.Param) {
+				return fmt.Errorf("validation: required_without names no field %q of %s", c.Param, t)
+			}
+		}
+
+		if err := check(field.Type, seen); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func known(name string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	_, ok := rules[name]
+
+	return ok || name == "required"
+}
+
+func hasField(t reflect.Type, name string) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if FieldName(t.Field(i)) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validates reports whether the struct t, or one it holds, has `validate` tags, the requests Validate can refuse.
+func Validates(t reflect.Type) bool {
+	return validates(t, make(map[reflect.Type]bool))
+}
+
+func validates(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || seen[t] {
+		return false
+	}
+
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() && (field.Tag.Get("validate") != "" || validates(field.Type, seen)) {
+			return true
+		}
+	}
+
+	return false
+}
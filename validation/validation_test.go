@@ -0,0 +1,173 @@
+package validation
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"myapp/appError"
+)
+
+type item struct {
+	Name string `json:"name" validate:"required,max=3"`
+}
+
+type request struct {
+	Name     string  `json:"name" validate:"required_without=template_id,max=5"`
+	Template int64   `json:"template_id"`
+	Title    *string `json:"title" validate:"notblank"`
+	Count    int     `query:"count" validate:"max=10"`
+	Tags     []int   `json:"tags" validate:"max=2"`
+	Kind     string  `json:"kind" validate:"oneof=a b"`
+	Items    []item  `json:"items"`
+}
+
+// fields lists the invalid fields of the error Validate returned, nil when it passed.
+func fields(t *testing.T, err error) []appError.FieldError {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	teqErr, ok := err.(appError.TeqError)
+	if !ok {
+		t.Fatalf("expected a TeqError, got %T: %v", err, err)
+	}
+
+	if teqErr.HTTPCode != http.StatusUnprocessableEntity {
+		t.Errorf("HTTPCode = %d, want %d", teqErr.HTTPCode, http.StatusUnprocessableEntity)
+	}
+
+	return teqErr.Fields
+}
+
+func TestValidate(t *testing.T) {
+	var (
+		blank = "  "
+		title = "title"
+	)
+
+	tests := []struct {
+		name string
+		req  request
+		want []appError.FieldError
+	}{
+		{"valid", request{Name: "card"}, nil},
+		{"name without template", request{}, []appError.FieldError{
+			{Field: "name", Code: "required_without", Message: "is required without `template_id`"},
+		}},
+		{"blank name without template", request{Name: "   "}, []appError.FieldError{
+			{Field: "name", Code: "required_without", Message: "is required without `template_id`"},
+		}},
+		{"template instead of name", request{Template: 1}, nil},
+		{"name too long", request{Name: "abcdef"}, []appError.FieldError{
+			{Field: "name", Code: "max", Message: "must be at most 5 characters long"},
+		}},
+		{"name at max", request{Name: "abcde"}, nil},
+		{"name trimmed to max", request{Name: " abcde "}, nil},
+		{"name counted in runes", request{Name: "ééééé"}, nil},
+		{"title left out", request{Name: "card", Title: nil}, nil},
+		{"title blank", request{Name: "card", Title: &blank}, []appError.FieldError{
+			{Field: "title", Code: "notblank", Message: "must not be blank"},
+		}},
+		{"title set", request{Name: "card", Title: &title}, nil},
+		{"count too big", request{Name: "card", Count: 11}, []appError.FieldError{
+			{Field: "count", Code: "max", Message: "must be at most 10"},
+		}},
+		{"too many tags", request{Name: "card", Tags: []int{1, 2, 3}}, []appError.FieldError{
+			{Field: "tags", Code: "max", Message: "must have at most 2 items"},
+		}},
+		{"kind out of the list", request{Name: "card", Kind: "c"}, []appError.FieldError{
+			{Field: "kind", Code: "oneof", Message: "must be one of: a, b"},
+		}},
+		{"nested items", request{Name: "card", Items: []item{{Name: "ok"}, {}, {Name: "long"}}}, []appError.FieldError{
+			{Field: "items[1].name", Code: "required", Message: "is required"},
+			{Field: "items[2].name", Code: "max", Message: "must be at most 3 characters long"},
+		}},
+		{"every invalid field", request{Name: "abcdef", Count: 11}, []appError.FieldError{
+			{Field: "name", Code: "max", Message: "must be at most 5 characters long"},
+			{Field: "count", Code: "max", Message: "must be at most 10"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+
+			got := fields(t, Validate(&req))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate fields = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	type unknownRule struct {
+		Name string `json:"name" validate:"required,shiny"`
+	}
+
+	type missingField struct {
+		Name string `json:"name" validate:"required_without=other"`
+	}
+
+	type nestedUnknownRule struct {
+		Items []*unknownRule `json:"items"`
+	}
+
+	tests := []struct {
+		name    string
+		t       reflect.Type
+		wantErr bool
+	}{
+		{"known rules", reflect.TypeOf(request{}), false},
+		{"through a pointer", reflect.TypeOf(&request{}), false},
+		{"not a struct", reflect.TypeOf(""), false},
+		{"unknown rule", reflect.TypeOf(unknownRule{}), true},
+		{"required_without an unknown field", reflect.TypeOf(missingField{}), true},
+		{"unknown rule of a nested struct", reflect.TypeOf(nestedUnknownRule{}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Check(tt.t); (err != nil) != tt.wantErr {
+				t.Errorf("Check = %v, want an error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUnknownRuleOfAnEmptyField(t *testing.T) {
+	type unknownRule struct {
+		Name string `json:"name" validate:"shiny"`
+	}
+
+	// the field is empty, the rule would never run, the tag is refused all the same
+	defer func() {
+		if recover() == nil {
+			t.Error("Validate accepted a tag with an unknown rule")
+		}
+	}()
+
+	_ = Validate(&unknownRule{})
+}
+
+func TestRegister(t *testing.T) {
+	type colored struct {
+		Color string `json:"color" validate:"kind=color"`
+	}
+
+	Register("kind", func(value reflect.Value, param string, _ reflect.Value) bool {
+		return param == "color" && value.String() == "red"
+	}, "must be a %s")
+
+	if got := fields(t, Validate(&colored{Color: "red"})); got != nil {
+		t.Errorf("Validate of a valid color = %+v", got)
+	}
+
+	want := []appError.FieldError{{Field: "color", Code: "kind", Message: "must be a color"}}
+	if got := fields(t, Validate(&colored{Color: "blue"})); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate of an invalid color = %+v, want %+v", got, want)
+	}
+}